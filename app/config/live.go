@@ -14,6 +14,17 @@ var Live = []byte(`
     "password":"",
 	"timeout":10,
     "enabled":false
+  },
+  "sweeper":{
+    "interval":10
+  },
+  "undo":{
+    "classic":1,
+    "multi-mine":1,
+    "practice":20
+  },
+  "events":{
+    "snapshotInterval":20
   }
 }
 `)
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/pedidosya/minesweeper-API/app/models"
 	"github.com/pedidosya/minesweeper-API/app/server"
@@ -12,11 +13,21 @@ import (
 
 type IHandlerGame interface {
 	NewGame(w http.ResponseWriter, r *http.Request)
+	GetGame(w http.ResponseWriter, r *http.Request)
 	PauseGame(w http.ResponseWriter, r *http.Request)
 	ResumeGame(w http.ResponseWriter, r *http.Request)
+	Undo(w http.ResponseWriter, r *http.Request)
+	Redo(w http.ResponseWriter, r *http.Request)
 	Uncover(w http.ResponseWriter, r *http.Request)
+	Chord(w http.ResponseWriter, r *http.Request)
+	Hint(w http.ResponseWriter, r *http.Request)
+	Probabilities(w http.ResponseWriter, r *http.Request)
+	Replay(w http.ResponseWriter, r *http.Request)
+	ReplayStep(w http.ResponseWriter, r *http.Request)
 	MarkRed(w http.ResponseWriter, r *http.Request)
 	MarkQuestion(w http.ResponseWriter, r *http.Request)
+	Unmark(w http.ResponseWriter, r *http.Request)
+	CycleMark(w http.ResponseWriter, r *http.Request)
 	FindGames(w http.ResponseWriter, r *http.Request)
 }
 
@@ -47,14 +58,41 @@ func (handler *HandlerGame) NewGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	game, err := handler.gameService.NewGame(newGameRequest.Rows, newGameRequest.Columns, newGameRequest.Mines, userLogin)
+	game, err := handler.gameService.NewGame(newGameRequest, userLogin)
 	if err != nil {
 		server.InternalServerError(w, r, err)
 		return
 	}
 
 	bodyResponse := make(map[string]interface{})
-	bodyResponse["game"] = game
+	bodyResponse["game"] = game.PlayerView()
+	server.OK(w, r, bodyResponse)
+}
+
+func (handler *HandlerGame) GetGame(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	game, err := handler.gameService.GetGame(gameId, userLogin)
+	if err != nil {
+		renderGameError(w, r, err)
+		return
+	}
+
+	bodyResponse := make(map[string]interface{})
+	bodyResponse["game"] = game.PlayerView()
 	server.OK(w, r, bodyResponse)
 }
 
@@ -75,8 +113,7 @@ func (handler *HandlerGame) PauseGame(w http.ResponseWriter, r *http.Request) {
 
 	isPaused, err := handler.gameService.PauseGame(gameId)
 	if err != nil {
-		utils.LogError(err)
-		server.InternalServerError(w, r, err)
+		renderGameError(w, r, err)
 		return
 	}
 
@@ -105,22 +142,49 @@ func (handler *HandlerGame) ResumeGame(w http.ResponseWriter, r *http.Request) {
 
 	game, err := handler.gameService.ResumeGame(gameId, userLogin)
 	if err != nil {
-		utils.LogError(err)
-		server.InternalServerError(w, r, err)
+		renderGameError(w, r, err)
 		return
 	}
 
 	bodyResponse := make(map[string]interface{})
-	bodyResponse["game"] = game
+	bodyResponse["game"] = game.PlayerView()
 	server.OK(w, r, bodyResponse)
 }
 
-func (handler *HandlerGame) MarkRed(w http.ResponseWriter, r *http.Request) {
+func (handler *HandlerGame) Undo(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	game, err := handler.gameService.Undo(gameId)
+	if err != nil {
+		renderGameError(w, r, err)
+		return
+	}
+
+	bodyResponse := make(map[string]interface{})
+	bodyResponse["game"] = game.PlayerView()
+	server.OK(w, r, bodyResponse)
+}
+
+func (handler *HandlerGame) Redo(w http.ResponseWriter, r *http.Request) {
 
 	gameId := server.GetStringFromPath(r, "game_id", "")
 	if gameId == "" {
 		err := fmt.Errorf("game id is mandatory")
 		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
 	}
 
 	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
@@ -130,6 +194,31 @@ func (handler *HandlerGame) MarkRed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	game, err := handler.gameService.Redo(gameId)
+	if err != nil {
+		renderGameError(w, r, err)
+		return
+	}
+
+	bodyResponse := make(map[string]interface{})
+	bodyResponse["game"] = game.PlayerView()
+	server.OK(w, r, bodyResponse)
+}
+
+func (handler *HandlerGame) MarkRed(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
 
 	var cellRequest *models.CellRequest
 
@@ -143,10 +232,9 @@ func (handler *HandlerGame) MarkRed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isMark, err := handler.gameService.MarkRed(gameId, cellRequest.Row, cellRequest.Column)
+	isMark, err := handler.gameService.MarkRed(gameId, cellRequest)
 	if err != nil {
-		utils.LogError(err)
-		server.InternalServerError(w, r, err)
+		renderGameError(w, r, err)
 		return
 	}
 
@@ -185,10 +273,93 @@ func (handler *HandlerGame) MarkQuestion(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	isMark, err := handler.gameService.MarkQuestion(gameId, cellRequest.Row, cellRequest.Column)
+	isMark, err := handler.gameService.MarkQuestion(gameId, cellRequest)
 	if err != nil {
-		utils.LogError(err)
-		server.InternalServerError(w, r, err)
+		renderGameError(w, r, err)
+		return
+	}
+
+	if !isMark {
+		server.NotFound(w, r, fmt.Sprintf("not found game wih gameId: %s", gameId))
+		return
+	}
+
+	server.OkNotContent(w, r)
+}
+
+func (handler *HandlerGame) Unmark(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	var cellRequest *models.CellRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&cellRequest); err != nil {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	if err := validateCellRequest(cellRequest); err != nil {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	isMark, err := handler.gameService.Unmark(gameId, cellRequest)
+	if err != nil {
+		renderGameError(w, r, err)
+		return
+	}
+
+	if !isMark {
+		server.NotFound(w, r, fmt.Sprintf("not found game wih gameId: %s", gameId))
+		return
+	}
+
+	server.OkNotContent(w, r)
+}
+
+func (handler *HandlerGame) CycleMark(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	var cellRequest *models.CellRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&cellRequest); err != nil {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	if err := validateCellRequest(cellRequest); err != nil {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	isMark, err := handler.gameService.CycleMark(gameId, cellRequest)
+	if err != nil {
+		renderGameError(w, r, err)
 		return
 	}
 
@@ -227,15 +398,175 @@ func (handler *HandlerGame) Uncover(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	game, err := handler.gameService.Uncover(gameId, cellRequest.Row, cellRequest.Column)
+	game, err := handler.gameService.Uncover(gameId, cellRequest)
 	if err != nil {
-		utils.LogError(err)
-		server.InternalServerError(w, r, err)
+		renderGameError(w, r, err)
+		return
+	}
+
+	bodyResponse := make(map[string]interface{})
+	bodyResponse["game"] = game.PlayerView()
+	server.OK(w, r, bodyResponse)
+}
+
+func (handler *HandlerGame) Chord(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	var cellRequest *models.CellRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&cellRequest); err != nil {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	if err := validateCellRequest(cellRequest); err != nil {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	game, err := handler.gameService.Chord(gameId, cellRequest)
+	if err != nil {
+		renderGameError(w, r, err)
+		return
+	}
+
+	bodyResponse := make(map[string]interface{})
+	bodyResponse["game"] = game.PlayerView()
+	server.OK(w, r, bodyResponse)
+}
+
+func (handler *HandlerGame) Hint(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	hint, err := handler.gameService.Hint(gameId)
+	if err != nil {
+		renderGameError(w, r, err)
+		return
+	}
+
+	bodyResponse := make(map[string]interface{})
+	bodyResponse["hint"] = hint
+	server.OK(w, r, bodyResponse)
+}
+
+func (handler *HandlerGame) Probabilities(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	probabilityMap, err := handler.gameService.Probabilities(gameId)
+	if err != nil {
+		renderGameError(w, r, err)
+		return
+	}
+
+	server.OK(w, r, probabilityMap)
+}
+
+func (handler *HandlerGame) Replay(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	replay, err := handler.gameService.Replay(gameId)
+	if err != nil {
+		renderGameError(w, r, err)
+		return
+	}
+
+	bodyResponse := make(map[string]interface{})
+	bodyResponse["replay"] = replay
+	server.OK(w, r, bodyResponse)
+}
+
+// ReplayStep returns the board after the event of the cursor, the first event when there is none,
+// with the cursor of the next event while there is one
+func (handler *HandlerGame) ReplayStep(w http.ResponseWriter, r *http.Request) {
+
+	gameId := server.GetStringFromPath(r, "game_id", "")
+	if gameId == "" {
+		err := fmt.Errorf("game id is mandatory")
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	cursor, err := server.GetIntFromQuery(r, "cursor", 1)
+	if err != nil {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	if cursor < 1 {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, "cursor must be greater than zero")
+		return
+	}
+
+	userLogin := handler.userService.UserLogin(r.Header.Get(authorizationHeader))
+
+	if userLogin == "" {
+		server.Forbidden(w, r, "invalid token")
+		return
+	}
+
+	step, next, err := handler.gameService.ReplayStep(gameId, cursor)
+	if err != nil {
+		renderGameError(w, r, err)
 		return
 	}
 
 	bodyResponse := make(map[string]interface{})
-	bodyResponse["game"] = game
+	bodyResponse["step"] = step
+	if next != 0 {
+		bodyResponse["next"] = next
+	}
 	server.OK(w, r, bodyResponse)
 }
 
@@ -250,41 +581,212 @@ func (handler *HandlerGame) FindGames(w http.ResponseWriter, r *http.Request) {
 	games, err := handler.gameService.FindGames(userLogin)
 
 	if err == nil {
-		server.OK(w, r, games)
+		server.OK(w, r, games.PlayerView())
 	} else {
 		server.InternalServerError(w, r, err)
 	}
 }
 
+// renderGameError answers 409 Conflict when the state of the game does not allow the action and 403 Forbidden
+// when the game of another player is asked while in play,
+// any other error is logged and is an internal error
+func renderGameError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, models.ErrReplayCursor) || errors.Is(err, models.ErrEndlessBounds) {
+		server.BadRequest(w, r, server.ErrorCodeInvalidParams, err.Error())
+		return
+	}
+
+	if errors.Is(err, models.ErrReplayInPlay) {
+		server.Conflict(w, r, server.ErrorCodeGameInPlay, err.Error())
+		return
+	}
+
+	if errors.Is(err, models.ErrForeignGame) {
+		server.Forbidden(w, r, err.Error())
+		return
+	}
+
+	if errors.Is(err, models.ErrVersionConflict) {
+		server.Conflict(w, r, server.ErrorCodeVersionConflict, err.Error())
+		return
+	}
+
+	if errors.Is(err, models.ErrNotAvailable) {
+		server.Conflict(w, r, server.ErrorCodeNotAvailable, err.Error())
+		return
+	}
+
+	if errors.Is(err, models.ErrOpenCell) {
+		server.Conflict(w, r, server.ErrorCodeCellOpen, err.Error())
+		return
+	}
+
+	if errors.Is(err, models.ErrNothingToUndo) || errors.Is(err, models.ErrNothingToRedo) ||
+		errors.Is(err, models.ErrUndoLimit) || errors.Is(err, models.ErrUndoLoss) {
+		server.Conflict(w, r, server.ErrorCodeUndoNotAllowed, err.Error())
+		return
+	}
+
+	var transitionError *models.TransitionError
+	if !errors.As(err, &transitionError) {
+		utils.LogError(err)
+		server.InternalServerError(w, r, err)
+		return
+	}
+
+	code := server.ErrorCodeGameFinished
+	if transitionError.State == models.Paused {
+		code = server.ErrorCodeGamePaused
+	}
+	server.Conflict(w, r, code, transitionError.Error())
+}
+
+// validateCellRequest only checks what holds on every board, endless boards take any row and column,
+// zero and negative ones included, so the game service checks the cell is on the board
 func validateCellRequest(cellRequest *models.CellRequest) error {
-	if cellRequest.Row == 0 {
-		return fmt.Errorf("row is mandatory and greater than zero")
+	if cellRequest.Layer < 0 {
+		return fmt.Errorf("layer must be greater than zero")
 	}
 
-	if cellRequest.Column == 0 {
-		return fmt.Errorf("columns is mandatory and greater than zero")
+	if cellRequest.Count < 0 {
+		return fmt.Errorf("count must be greater than zero")
 	}
 
 	return nil
 }
 
 func validateNewGameRequest(newGameRequest *models.NewGameRequest) error {
-	if newGameRequest.Rows == 0 {
-		return fmt.Errorf("rows is mandatory and greater than zero")
-	}
+	if newGameRequest.TimeLimit < 0 {
+		return fmt.Errorf("timeLimit must be greater than zero")
+	}
+
+	if newGameRequest.Endless {
+		return validateEndlessGameRequest(newGameRequest)
+	}
+
+	cells := newGameRequest.Rows * newGameRequest.Columns
+	rows := newGameRequest.Rows
+	if newGameRequest.Mask != "" {
+		shape, err := models.ParseMask(newGameRequest.Mask)
+		if err != nil {
+			return err
+		}
+		// the mask already gives the size of the board, rows and columns may be left out
+		if newGameRequest.Rows != 0 && newGameRequest.Rows != shape.Rows {
+			return fmt.Errorf("rows must be the rows of the mask: %d", shape.Rows)
+		}
+		if newGameRequest.Columns != 0 && newGameRequest.Columns != shape.Columns {
+			return fmt.Errorf("columns must be the columns of the mask: %d", shape.Columns)
+		}
+		cells = shape.Cells()
+		rows = shape.Rows
+	} else {
+		if newGameRequest.Rows == 0 {
+			return fmt.Errorf("rows is mandatory and greater than zero")
+		}
 
-	if newGameRequest.Columns == 0 {
-		return fmt.Errorf("columns is mandatory and greater than zero")
+		if newGameRequest.Columns == 0 {
+			return fmt.Errorf("columns is mandatory and greater than zero")
+		}
 	}
 
 	if newGameRequest.Mines == 0 {
 		return fmt.Errorf("mines is mandatory and greater than zero")
 	}
 
-	if newGameRequest.Rows*newGameRequest.Columns <= newGameRequest.Mines {
+	if newGameRequest.Layers < 0 {
+		return fmt.Errorf("layers must be greater than zero")
+	}
+
+	layers := newGameRequest.Layers
+	if layers == 0 {
+		layers = 1
+	}
+	if newGameRequest.Mode != "" && !models.IsValidGameMode(newGameRequest.Mode) {
+		return fmt.Errorf("mode %s is not registered", newGameRequest.Mode)
+	}
+
+	maxMinesPerCell := 1
+	if newGameRequest.Mode == models.GameModeMultiMine {
+		if newGameRequest.MaxMinesPerCell < 2 {
+			return fmt.Errorf("maxMinesPerCell is mandatory and greater than one on %s boards", models.GameModeMultiMine)
+		}
+		if newGameRequest.NoGuess {
+			return fmt.Errorf("noGuess is not available on %s boards", models.GameModeMultiMine)
+		}
+		maxMinesPerCell = newGameRequest.MaxMinesPerCell
+	} else if newGameRequest.MaxMinesPerCell > 1 {
+		return fmt.Errorf("maxMinesPerCell needs the mode: %s", models.GameModeMultiMine)
+	}
+
+	if newGameRequest.Lives < 0 {
+		return fmt.Errorf("lives must be greater than zero")
+	}
+
+	if newGameRequest.Lives > 0 && newGameRequest.Mode != models.GameModePractice {
+		return fmt.Errorf("lives needs the mode: %s", models.GameModePractice)
+	}
+
+	// at least one cell is left without mines
+	if (layers*cells-1)*maxMinesPerCell < newGameRequest.Mines {
 		return fmt.Errorf("the board must have more cells than mines")
 	}
 
+	switch newGameRequest.FirstClick {
+	case "", models.FirstClickOff, models.FirstClickSafeCell, models.FirstClickSafeOpening:
+	default:
+		return fmt.Errorf("firstClick must be one of: %s, %s, %s",
+			models.FirstClickOff, models.FirstClickSafeCell, models.FirstClickSafeOpening)
+	}
+
+	if newGameRequest.Topology != "" && !models.IsValidTopology(newGameRequest.Topology) {
+		return fmt.Errorf("topology must be one of: %s, %s", models.TopologyFlat, models.TopologyTorus)
+	}
+
+	if newGameRequest.Layout != "" && !models.IsValidLayout(newGameRequest.Layout) {
+		return fmt.Errorf("layout must be one of: %s, %s", models.LayoutSquare, models.LayoutHex)
+	}
+
+	if newGameRequest.Layout == models.LayoutHex && newGameRequest.Topology == models.TopologyTorus && rows%2 != 0 {
+		return fmt.Errorf("hex boards need an even number of rows to wrap around")
+	}
+
+	if newGameRequest.NoGuess && newGameRequest.FirstClick != "" && newGameRequest.FirstClick != models.FirstClickSafeOpening {
+		return fmt.Errorf("noGuess needs the firstClick: %s", models.FirstClickSafeOpening)
+	}
+
+	if newGameRequest.NoGuess && layers*cells > models.MaxNoGuessCells {
+		return fmt.Errorf("noGuess boards may have up to %d cells", models.MaxNoGuessCells)
+	}
+
+	return nil
+}
+
+// validateEndlessGameRequest checks the mines, which are per chunk on endless boards, and that nothing
+// that needs a board with edges was asked for
+func validateEndlessGameRequest(newGameRequest *models.NewGameRequest) error {
+	maxMines := models.ChunkSize*models.ChunkSize - 9
+	if newGameRequest.Mines < 1 || maxMines < newGameRequest.Mines {
+		return fmt.Errorf("mines on each chunk of an endless board must be between 1 and: %d", maxMines)
+	}
+
+	if newGameRequest.Rows != 0 || newGameRequest.Columns != 0 || newGameRequest.Layers > 1 || newGameRequest.Mask != "" {
+		return fmt.Errorf("endless boards have no rows, columns, layers or mask")
+	}
+
+	if (newGameRequest.Topology != "" && newGameRequest.Topology != models.TopologyFlat) ||
+		(newGameRequest.Layout != "" && newGameRequest.Layout != models.LayoutSquare) {
+		return fmt.Errorf("endless boards are %s and %s", models.TopologyFlat, models.LayoutSquare)
+	}
+
+	if (newGameRequest.Mode != "" && newGameRequest.Mode != models.GameModeClassic) || newGameRequest.Lives != 0 {
+		return fmt.Errorf("endless boards use the mode: %s", models.GameModeClassic)
+	}
+
+	if newGameRequest.NoGuess || newGameRequest.FirstClick != "" {
+		return fmt.Errorf("endless boards start uncovered at the cell 0, 0, noGuess and firstClick do not apply")
+	}
+
 	return nil
 }
 
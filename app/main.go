@@ -5,6 +5,7 @@ import (
 	"flag"
 	"github.com/pedidosya/minesweeper-API/app/config"
 	"github.com/pedidosya/minesweeper-API/app/server"
+	"github.com/pedidosya/minesweeper-API/app/services"
 	"github.com/pedidosya/minesweeper-API/utils"
 	"github.com/spf13/viper"
 	"log"
@@ -37,6 +38,11 @@ func startWebServer() {
 
 	Routes(s)
 
+	// the sweeper expires the timed games nobody plays any more
+	sweeper := services.NewGameSweeper()
+	sweeper.Start()
+	defer sweeper.Stop()
+
 	utils.LogInfo("starting http listener ...")
 	go func() {
 		s.ListenAndServe()
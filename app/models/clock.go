@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Clock tells the time of an action, the game service takes one so the time can be set by hand on tests
+type Clock func() time.Time
+
+// Pause is an interval the game spent paused, ResumedAt is nil while it lasts
+type Pause struct {
+	PausedAt  time.Time  `bson:"paused_at" json:"pausedAt"`
+	ResumedAt *time.Time `bson:"resumed_at" json:"resumedAt,omitempty"`
+}
+
+// moves are the actions that start the clock, the time before the first move is not played
+var moves = map[GameAction]bool{
+	ActionUncover:      true,
+	ActionChord:        true,
+	ActionMarkRed:      true,
+	ActionMarkQuestion: true,
+	ActionUnmark:       true,
+	ActionCycleMark:    true,
+}
+
+// track starts the clock on the first move and opens and closes the pauses, the action is already allowed
+func (game *Game) track(action GameAction, previous StateGame, now time.Time) {
+	switch {
+	case moves[action] && game.StartedAt == nil:
+		game.StartedAt = &now
+	case action == ActionPause:
+		game.Pauses = append(game.Pauses, &Pause{PausedAt: now})
+	case action == ActionResume && previous == Paused && len(game.Pauses) > 0:
+		game.Pauses[len(game.Pauses)-1].ResumedAt = &now
+	}
+}
+
+// UpdateClock freezes the clock once the game is won or lost and sets ElapsedMs, it is called after every action
+func (game *Game) UpdateClock(now time.Time) {
+	if (game.State == Won || game.State == Lose) && game.EndedAt == nil {
+		game.EndedAt = &now
+	}
+	game.ElapsedMs = game.Elapsed(now).Milliseconds()
+}
+
+// Elapsed is the time played from the first move to the end of the game, or to now while it goes on, less the pauses
+func (game *Game) Elapsed(now time.Time) time.Duration {
+	if game.StartedAt == nil {
+		return 0
+	}
+	end := now
+	if game.EndedAt != nil {
+		end = *game.EndedAt
+	}
+
+	elapsed := end.Sub(*game.StartedAt)
+	for _, pause := range game.Pauses {
+		from, to := pause.PausedAt, end
+		if from.Before(*game.StartedAt) {
+			from = *game.StartedAt
+		}
+		if pause.ResumedAt != nil && pause.ResumedAt.Before(end) {
+			to = *pause.ResumedAt
+		}
+		if to.After(from) {
+			elapsed -= to.Sub(from)
+		}
+	}
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
+}
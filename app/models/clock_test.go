@@ -0,0 +1,51 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// at is the time the given seconds after the start of the tests, the clock of the games moves by hand
+func at(seconds int) time.Time {
+	return time.Date(2020, 1, 1, 0, 0, seconds, 0, time.UTC)
+}
+
+func TestGame_Elapsed(t *testing.T) {
+	game := newMinedGame(3, 3, 0)
+
+	game.Transition(ActionPause, at(1))
+	game.Transition(ActionResume, at(5))
+	game.UpdateClock(at(10))
+	assert.Equal(t, int64(0), game.ElapsedMs)
+
+	game.Transition(ActionMarkRed, at(10))
+	game.MarkRed(&CellRequest{Row: 1, Column: 1})
+	game.UpdateClock(at(12))
+	assert.Equal(t, int64(2000), game.ElapsedMs)
+
+	game.Transition(ActionPause, at(13))
+	assert.Equal(t, 3*time.Second, game.Elapsed(at(100)))
+	game.Transition(ActionResume, at(100))
+	assert.Equal(t, 3*time.Second, game.Elapsed(at(100)))
+	assert.Equal(t, 5*time.Second, game.Elapsed(at(102)))
+
+	game.Transition(ActionUncover, at(104))
+	game.UncoverCell(&CellRequest{Row: 3, Column: 3})
+	game.UpdateClock(at(104))
+	assert.Equal(t, Won, game.State)
+	assert.Equal(t, at(104), *game.EndedAt)
+
+	game.UpdateClock(at(500))
+	assert.Equal(t, int64(7000), game.ElapsedMs)
+	assert.Len(t, game.Pauses, 2)
+}
+
+func TestGame_Elapsed_ResumeInPlay(t *testing.T) {
+	game := &Game{State: Playing}
+	game.Transition(ActionUncover, at(0))
+	game.Transition(ActionResume, at(3))
+
+	assert.Empty(t, game.Pauses)
+	assert.Equal(t, 4*time.Second, game.Elapsed(at(4)))
+}
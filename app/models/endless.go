@@ -0,0 +1,286 @@
+package models
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	// ChunkSize is the side of the square chunks an endless board is generated and stored in
+	ChunkSize = 16
+	// MaxEndlessReveal caps the cells a single uncover opens on an endless board,
+	// the rest of the opening stays covered until the player uncovers it
+	MaxEndlessReveal = 4096
+	// MaxEndlessCoordinate bounds the rows and columns of an endless board, far beyond any game played
+	// and near enough to the cell 0, 0 for the chunk arithmetic not to overflow
+	MaxEndlessCoordinate = 1 << 40
+)
+
+var ErrEndlessBounds = fmt.Errorf("the row and column of an endless board must be between -%d and %d",
+	MaxEndlessCoordinate, MaxEndlessCoordinate)
+
+// Chunk is a square of cells of an endless board. Rows and columns count chunks, the chunk 0, 0 starts at the cell 0, 0.
+type Chunk struct {
+	Id     string  `bson:"_id" json:"-"`
+	GameId string  `bson:"game_id" json:"-"`
+	Row    int     `bson:"row" json:"row"`
+	Column int     `bson:"column" json:"column"`
+	Cells  []*Cell `bson:"cells" json:"cells"`
+}
+
+// ChunkLoader returns the chunk saved by an earlier move, or nil when no move touched it yet
+type ChunkLoader func(row int, column int) (*Chunk, error)
+
+// EndlessBoard has no edges: every chunk is generated from the seed of the game the first time a move touches it,
+// and only the touched chunks are stored. There is no win, the score is the cells cleared before the first mine.
+// Cells may have any row and column, negative ones included, and the game starts with the cell 0, 0 uncovered.
+type EndlessBoard struct {
+	// Mines is the number of mines on each chunk
+	Mines int `bson:"mines" json:"mines"`
+	Score int `bson:"score" json:"score"`
+	// Chunks are the chunks the last move touched, they are stored apart from the game
+	Chunks []*Chunk `bson:"-" json:"chunks"`
+
+	seed    int64
+	loader  ChunkLoader
+	chunks  map[chunkKey]*Chunk
+	layouts map[chunkKey][]bool
+}
+
+type chunkKey struct {
+	row    int
+	column int
+}
+
+// endlessOffsets are the steps to the cells around, endless boards are square
+var endlessOffsets = squareOffsets
+
+func NewEndlessBoard(mines int) *EndlessBoard {
+	return &EndlessBoard{Mines: mines}
+}
+
+// ValidateEndlessCell checks the row and column of the cell are within MaxEndlessCoordinate
+func ValidateEndlessCell(position *CellRequest) error {
+	if position.Row < -MaxEndlessCoordinate || MaxEndlessCoordinate < position.Row ||
+		position.Column < -MaxEndlessCoordinate || MaxEndlessCoordinate < position.Column {
+		return ErrEndlessBounds
+	}
+	return nil
+}
+
+// UncoverEndless opens the cell and floods through the cells without mines around, up to MaxEndlessReveal cells
+func (game *Game) UncoverEndless(position *CellRequest, loader ChunkLoader) error {
+	board := game.endless(loader)
+	if game.State != Playing {
+		return nil
+	}
+	cell, err := board.cell(position.Row, position.Column)
+	if err != nil {
+		return err
+	}
+	if cell.IsOpen || cell.Flag == FlagRed {
+		return nil
+	}
+	if cell.IsMined {
+		cell.Exploded = true
+		game.ExplodedMines++
+		game.State = Lose
+		game.LossReason = LossReasonMine
+		return nil
+	}
+	return board.reveal(position.Row, position.Column)
+}
+
+// ChordEndless uncovers every unflagged neighbour of an open number once it has as many red flags around as mines
+func (game *Game) ChordEndless(position *CellRequest, loader ChunkLoader) error {
+	board := game.endless(loader)
+	if game.State != Playing {
+		return nil
+	}
+	cell, err := board.cell(position.Row, position.Column)
+	if err != nil {
+		return err
+	}
+	if !cell.IsOpen || cell.MinesAround == 0 {
+		return nil
+	}
+
+	redFlags := 0
+	for _, offset := range endlessOffsets {
+		adjacent, err := board.cell(position.Row+offset[0], position.Column+offset[1])
+		if err != nil {
+			return err
+		}
+		if adjacent.Flag == FlagRed {
+			redFlags++
+		}
+	}
+	if redFlags != cell.MinesAround {
+		return nil
+	}
+
+	for _, offset := range endlessOffsets {
+		adjacent := &CellRequest{Row: position.Row + offset[0], Column: position.Column + offset[1]}
+		adjacentCell, err := board.cell(adjacent.Row, adjacent.Column)
+		if err != nil {
+			return err
+		}
+		if adjacentCell.Flag == FlagRed || adjacentCell.IsOpen {
+			continue
+		}
+		if err := game.UncoverEndless(adjacent, loader); err != nil {
+			return err
+		}
+		if game.State == Lose {
+			break
+		}
+	}
+	return nil
+}
+
+func (game *Game) MarkRedEndless(position *CellRequest, loader ChunkLoader) error {
+	return game.markEndless(position, loader, func(FlagType) FlagType { return FlagRed })
+}
+
+func (game *Game) MarkQuestionEndless(position *CellRequest, loader ChunkLoader) error {
+	return game.markEndless(position, loader, func(FlagType) FlagType { return FlagQuestion })
+}
+
+func (game *Game) UnmarkEndless(position *CellRequest, loader ChunkLoader) error {
+	return game.markEndless(position, loader, func(FlagType) FlagType { return FlagNone })
+}
+
+func (game *Game) CycleMarkEndless(position *CellRequest, loader ChunkLoader) error {
+	return game.markEndless(position, loader, FlagType.next)
+}
+
+// markEndless replaces the mark of a covered cell with the one flag returns for it
+func (game *Game) markEndless(position *CellRequest, loader ChunkLoader, flag func(FlagType) FlagType) error {
+	cell, err := game.endless(loader).cell(position.Row, position.Column)
+	if err != nil {
+		return err
+	}
+	if cell.IsOpen {
+		return ErrOpenCell
+	}
+	cell.Flag = flag(cell.Flag)
+	return nil
+}
+
+// endless gets the board ready for a move, the chunks are loaded the first time the move needs them
+func (game *Game) endless(loader ChunkLoader) *EndlessBoard {
+	board := game.Endless
+	if board.chunks == nil {
+		board.chunks = make(map[chunkKey]*Chunk)
+		board.layouts = make(map[chunkKey][]bool)
+	}
+	board.seed = game.Seed
+	board.loader = loader
+	return board
+}
+
+func (board *EndlessBoard) reveal(row int, column int) error {
+	first, err := board.cell(row, column)
+	if err != nil {
+		return err
+	}
+	first.IsOpen = true
+	opened := [][2]int{{row, column}}
+	for next := 0; next < len(opened) && len(opened) < MaxEndlessReveal; next++ {
+		current, err := board.cell(opened[next][0], opened[next][1])
+		if err != nil {
+			return err
+		}
+		if current.MinesAround != 0 {
+			continue
+		}
+		for _, offset := range endlessOffsets {
+			adjacentRow, adjacentColumn := opened[next][0]+offset[0], opened[next][1]+offset[1]
+			adjacent, err := board.cell(adjacentRow, adjacentColumn)
+			if err != nil {
+				return err
+			}
+			if adjacent.IsOpen || adjacent.IsMined || adjacent.Flag == FlagRed || len(opened) == MaxEndlessReveal {
+				continue
+			}
+			adjacent.IsOpen = true
+			opened = append(opened, [2]int{adjacentRow, adjacentColumn})
+		}
+	}
+	board.Score = board.Score + len(opened)
+	return nil
+}
+
+// cell returns the cell, loading or generating its chunk the first time the move touches it
+func (board *EndlessBoard) cell(row int, column int) (*Cell, error) {
+	key := chunkKey{row: floorDiv(row, ChunkSize), column: floorDiv(column, ChunkSize)}
+	chunk, ok := board.chunks[key]
+	if !ok {
+		var err error
+		if board.loader != nil {
+			if chunk, err = board.loader(key.row, key.column); err != nil {
+				return nil, fmt.Errorf("it's not possible to load the chunk %d, %d, error :%v", key.row, key.column, err)
+			}
+		}
+		if chunk == nil {
+			chunk = board.newChunk(key)
+		}
+		board.chunks[key] = chunk
+		board.Chunks = append(board.Chunks, chunk)
+	}
+	return chunk.Cells[(row-key.row*ChunkSize)*ChunkSize+column-key.column*ChunkSize], nil
+}
+
+func (board *EndlessBoard) newChunk(key chunkKey) *Chunk {
+	chunk := &Chunk{Row: key.row, Column: key.column, Cells: make([]*Cell, ChunkSize*ChunkSize)}
+	for cellIndex := range chunk.Cells {
+		row, column := key.row*ChunkSize+cellIndex/ChunkSize, key.column*ChunkSize+cellIndex%ChunkSize
+		cell := &Cell{IsMined: board.isMined(row, column), Flag: FlagNone}
+		for _, offset := range endlessOffsets {
+			if board.isMined(row+offset[0], column+offset[1]) {
+				cell.MinesAround++
+			}
+		}
+		chunk.Cells[cellIndex] = cell
+	}
+	return chunk
+}
+
+// isMined looks at the layout drawn for the chunk, which only depends on the seed and the chunk,
+// so the numbers on the edge of a chunk are right before the chunk next to it is generated
+func (board *EndlessBoard) isMined(row int, column int) bool {
+	key := chunkKey{row: floorDiv(row, ChunkSize), column: floorDiv(column, ChunkSize)}
+	layout, ok := board.layouts[key]
+	if !ok {
+		layout = board.drawLayout(key)
+		board.layouts[key] = layout
+	}
+	return layout[(row-key.row*ChunkSize)*ChunkSize+column-key.column*ChunkSize]
+}
+
+// drawLayout places the mines of the chunk with its own random source, the cells around 0, 0 are always safe
+func (board *EndlessBoard) drawLayout(key chunkKey) []bool {
+	random := rand.New(rand.NewSource(board.seed ^ int64(uint32(key.row))<<32 ^ int64(uint32(key.column))))
+	candidates := make([]int, 0, ChunkSize*ChunkSize)
+	for cellIndex := 0; cellIndex < ChunkSize*ChunkSize; cellIndex++ {
+		row, column := key.row*ChunkSize+cellIndex/ChunkSize, key.column*ChunkSize+cellIndex%ChunkSize
+		if row < -1 || row > 1 || column < -1 || column > 1 {
+			candidates = append(candidates, cellIndex)
+		}
+	}
+
+	layout := make([]bool, ChunkSize*ChunkSize)
+	for i := 0; i < board.Mines && i < len(candidates); i++ {
+		j := i + random.Intn(len(candidates)-i)
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+		layout[candidates[i]] = true
+	}
+	return layout
+}
+
+func floorDiv(a int, b int) int {
+	if a < 0 {
+		return -((-a + b - 1) / b)
+	}
+	return a / b
+}
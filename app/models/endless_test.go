@@ -0,0 +1,136 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func newEndlessGame(mines int, seed int64) *Game {
+	return &Game{State: Playing, Seed: seed, Endless: NewEndlessBoard(mines)}
+}
+
+func TestFloorDiv(t *testing.T) {
+	tests := []struct {
+		a    int
+		want int
+	}{
+		{a: 0, want: 0},
+		{a: 15, want: 0},
+		{a: 16, want: 1},
+		{a: -1, want: -1},
+		{a: -16, want: -1},
+		{a: -17, want: -2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, floorDiv(tt.a, ChunkSize))
+	}
+}
+
+func TestValidateEndlessCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		position CellRequest
+		wantErr  bool
+	}{
+		{name: "Origin", position: CellRequest{}},
+		{name: "Bounds", position: CellRequest{Row: -MaxEndlessCoordinate, Column: MaxEndlessCoordinate}},
+		{name: "Row past the bound", position: CellRequest{Row: MaxEndlessCoordinate + 1}, wantErr: true},
+		{name: "Column past the bound", position: CellRequest{Column: -MaxEndlessCoordinate - 1}, wantErr: true},
+		{name: "Lowest int", position: CellRequest{Row: math.MinInt64, Column: 3}, wantErr: true},
+		{name: "Highest int", position: CellRequest{Row: 3, Column: math.MaxInt64}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, ValidateEndlessCell(&tt.position) != nil)
+		})
+	}
+}
+
+func TestGame_UncoverEndless_Origin(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		game := newEndlessGame(40, seed)
+
+		err := game.UncoverEndless(&CellRequest{}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Playing, game.State)
+		assert.True(t, game.Endless.Score >= 9)
+		for _, chunk := range game.Endless.Chunks {
+			mines := 0
+			for cellIndex, cell := range chunk.Cells {
+				row, column := chunk.Row*ChunkSize+cellIndex/ChunkSize, chunk.Column*ChunkSize+cellIndex%ChunkSize
+				if cell.IsMined {
+					mines++
+				}
+				// the numbers on the edges count the mines of the chunks next to it too
+				minesAround := 0
+				for _, offset := range endlessOffsets {
+					if game.Endless.isMined(row+offset[0], column+offset[1]) {
+						minesAround++
+					}
+				}
+				assert.Equal(t, minesAround, cell.MinesAround)
+			}
+			assert.True(t, mines <= 40)
+		}
+	}
+}
+
+func TestGame_UncoverEndless_SameSeedSameChunks(t *testing.T) {
+	game := newEndlessGame(40, 7)
+	other := newEndlessGame(40, 7)
+
+	game.UncoverEndless(&CellRequest{Row: -40, Column: 100}, nil)
+	other.UncoverEndless(&CellRequest{Row: -40, Column: 100}, nil)
+
+	assert.Equal(t, game.State, other.State)
+	assert.Equal(t, game.Endless.Chunks, other.Endless.Chunks)
+	assert.Equal(t, -3, game.Endless.Chunks[0].Row)
+	assert.Equal(t, 6, game.Endless.Chunks[0].Column)
+}
+
+func TestGame_UncoverEndless_LoadsSavedChunks(t *testing.T) {
+	game := newEndlessGame(40, 3)
+	game.UncoverEndless(&CellRequest{}, nil)
+	game.MarkRedEndless(&CellRequest{Row: 20, Column: 20}, nil)
+	saved := make(map[chunkKey]*Chunk)
+	for _, chunk := range game.Endless.Chunks {
+		saved[chunkKey{row: chunk.Row, column: chunk.Column}] = chunk
+	}
+
+	resumed := &Game{State: Playing, Seed: 3, Endless: &EndlessBoard{Mines: 40, Score: game.Endless.Score}}
+	loader := func(row int, column int) (*Chunk, error) {
+		return saved[chunkKey{row: row, column: column}], nil
+	}
+	resumed.UncoverEndless(&CellRequest{}, loader)
+
+	assert.Equal(t, game.Endless.Score, resumed.Endless.Score)
+	cell, _ := resumed.Endless.cell(20, 20)
+	assert.Equal(t, FlagRed, cell.Flag)
+}
+
+func TestGame_UncoverEndless_RevealCap(t *testing.T) {
+	game := newEndlessGame(0, 1)
+
+	game.UncoverEndless(&CellRequest{}, nil)
+
+	assert.Equal(t, MaxEndlessReveal, game.Endless.Score)
+	assert.Equal(t, Playing, game.State)
+}
+
+func TestGame_UncoverEndless_Mine(t *testing.T) {
+	game := newEndlessGame(200, 1)
+	game.UncoverEndless(&CellRequest{}, nil)
+	score := game.Endless.Score
+
+	for row := 2; game.State == Playing; row++ {
+		if game.Endless.isMined(row, 0) {
+			game.UncoverEndless(&CellRequest{Row: row, Column: 0}, nil)
+		}
+	}
+	game.UncoverEndless(&CellRequest{Row: 0, Column: 5}, nil)
+
+	assert.Equal(t, Lose, game.State)
+	assert.Equal(t, score, game.Endless.Score)
+}
@@ -0,0 +1,222 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrVersionConflict is returned when another move stored the events of the game first, the move is not stored
+var ErrVersionConflict = errors.New("the game was changed by another move, it has to be loaded again")
+
+type EventType string
+
+const (
+	EventCreated   EventType = "created"
+	EventUncovered EventType = "uncovered"
+	EventChorded   EventType = "chorded"
+	EventFlagged   EventType = "flagged"
+	EventPaused    EventType = "paused"
+	EventResumed   EventType = "resumed"
+	EventHinted    EventType = "hinted"
+	EventUndone    EventType = "undone"
+	EventRedone    EventType = "redone"
+	EventWon       EventType = "won"
+	EventLost      EventType = "lost"
+)
+
+// eventActions are the actions the events go through the state machine with, flagged events carry their own
+var eventActions = map[EventType]GameAction{
+	EventUncovered: ActionUncover,
+	EventChorded:   ActionChord,
+	EventPaused:    ActionPause,
+	EventResumed:   ActionResume,
+	EventHinted:    ActionHint,
+	EventUndone:    ActionUndo,
+	EventRedone:    ActionRedo,
+}
+
+// markActions are the actions a flagged event may carry
+var markActions = map[GameAction]bool{
+	ActionMarkRed:      true,
+	ActionMarkQuestion: true,
+	ActionUnmark:       true,
+	ActionCycleMark:    true,
+}
+
+// GameEvent is an action that changed the game, the game is the events applied in order of Seq.
+// Only the fields of its type are set.
+type GameEvent struct {
+	Id     string    `bson:"_id" json:"-"`
+	GameId string    `bson:"game_id" json:"gameId"`
+	Seq    int       `bson:"seq" json:"seq"`
+	Type   EventType `bson:"type" json:"type"`
+	At     time.Time `bson:"at" json:"at"`
+	// Request, Seed and UndoLimit are what a created game was made from, the same board is generated from them
+	Request   *NewGameRequest `bson:"request,omitempty" json:"request,omitempty"`
+	Seed      int64           `bson:"seed,omitempty" json:"seed,string,omitempty"`
+	UndoLimit int             `bson:"undo_limit,omitempty" json:"undoLimit,omitempty"`
+	// Cell is the cell of a move and Action the mark of a flagged event
+	Cell   *CellRequest `bson:"cell,omitempty" json:"cell,omitempty"`
+	Action GameAction   `bson:"action,omitempty" json:"action,omitempty"`
+	// MineLayout is set on the uncover that laid the mines, no guess layouts depend on the time they were drawn in
+	MineLayout *MineLayout `bson:"mine_layout,omitempty" json:"-"`
+	LossReason LossReason  `bson:"loss_reason,omitempty" json:"lossReason,omitempty"`
+}
+
+// MineLayout is the mines laid on the first uncover, a cell is repeated for each of its mines
+type MineLayout struct {
+	Cells           []int `bson:"cells" json:"cells"`
+	NoGuessAttempts int   `bson:"no_guess_attempts" json:"noGuessAttempts"`
+	NoGuessFallback bool  `bson:"no_guess_fallback" json:"noGuessFallback"`
+}
+
+// NewEvent returns the event that follows the last one applied to the game
+func (game *Game) NewEvent(eventType EventType, at time.Time) *GameEvent {
+	return &GameEvent{Seq: game.Version + 1, Type: eventType, At: at}
+}
+
+// ApplyEvent makes the action of the event on the game, new events and stored ones alike, so a game rebuilt
+// from its events is the game that was played. Endless moves load their chunks with the loader.
+func (game *Game) ApplyEvent(event *GameEvent, loader ChunkLoader) error {
+	if event.Seq != game.Version+1 {
+		return fmt.Errorf("the event %d does not follow the version %d of the game", event.Seq, game.Version)
+	}
+
+	switch event.Type {
+	case EventCreated, EventWon:
+	case EventLost:
+		// the loss of a move is already on the game, only the time running out is not
+		if event.LossReason == LossReasonTimeout {
+			game.Expire(event.At)
+		}
+	default:
+		action, ok := eventActions[event.Type]
+		if event.Type == EventFlagged {
+			action, ok = event.Action, markActions[event.Action]
+		}
+		if !ok {
+			return fmt.Errorf("the event type %s is not known", event.Type)
+		}
+		if err := game.Transition(action, event.At); err != nil {
+			return err
+		}
+		if err := game.applyMove(event, action, loader); err != nil {
+			return err
+		}
+	}
+
+	game.Version = event.Seq
+	game.UpdateClock(event.At)
+	return nil
+}
+
+// SnapshotDue tells whether the snapshot of the game is stored after the events just appended: every time
+// the state changes and every time the version goes past a multiple of the interval
+func (game *Game) SnapshotDue(previous StateGame, appended int, interval int) bool {
+	return game.State != previous || game.Version%interval < appended
+}
+
+func (game *Game) applyMove(event *GameEvent, action GameAction, loader ChunkLoader) error {
+	switch action {
+	case ActionUncover:
+		if game.Endless != nil {
+			return game.UncoverEndless(event.Cell, loader)
+		}
+		game.uncoverEvent(event)
+	case ActionChord:
+		if game.Endless != nil {
+			return game.ChordEndless(event.Cell, loader)
+		}
+		game.ChordCell(event.Cell)
+	case ActionMarkRed, ActionMarkQuestion, ActionUnmark, ActionCycleMark:
+		if game.Endless != nil {
+			return game.markEndlessAction(event.Cell, action, loader)
+		}
+		return game.markAction(event.Cell, action)
+	case ActionHint:
+		game.HintsUsed++
+	case ActionUndo:
+		return game.Undo()
+	case ActionRedo:
+		return game.Redo()
+	}
+	return nil
+}
+
+// uncoverEvent uncovers the cell of the event, the mines laid on it are kept on the event the first time
+// and laid again from it afterwards
+func (game *Game) uncoverEvent(event *GameEvent) {
+	board := game.Board
+	cellIndex := board.calculateCell(event.Cell)
+	if event.MineLayout == nil || !board.PendingMines || board.Cells[cellIndex].Flag == FlagRed {
+		pending := board.PendingMines
+		game.UncoverCell(event.Cell)
+		if pending && !board.PendingMines {
+			event.MineLayout = &MineLayout{
+				Cells:           board.mineCells(),
+				NoGuessAttempts: board.NoGuessAttempts,
+				NoGuessFallback: game.NoGuessFallback,
+			}
+		}
+		return
+	}
+
+	board.layMines(event.MineLayout.Cells)
+	board.NoGuessAttempts = event.MineLayout.NoGuessAttempts
+	game.NoGuessFallback = event.MineLayout.NoGuessFallback
+	game.forgetMoves()
+	game.uncover(cellIndex)
+}
+
+func (game *Game) markAction(cell *CellRequest, action GameAction) error {
+	switch action {
+	case ActionMarkRed:
+		return game.MarkRed(cell)
+	case ActionMarkQuestion:
+		return game.MarkQuestion(cell)
+	case ActionUnmark:
+		return game.Unmark(cell)
+	}
+	return game.CycleMark(cell)
+}
+
+func (game *Game) markEndlessAction(cell *CellRequest, action GameAction, loader ChunkLoader) error {
+	switch action {
+	case ActionMarkRed:
+		return game.MarkRedEndless(cell, loader)
+	case ActionMarkQuestion:
+		return game.MarkQuestionEndless(cell, loader)
+	case ActionUnmark:
+		return game.UnmarkEndless(cell, loader)
+	}
+	return game.CycleMarkEndless(cell, loader)
+}
+
+// mineCells are the mined cells, a cell is repeated for each of its mines
+func (board *Board) mineCells() []int {
+	var cells []int
+	for cellIndex, cell := range board.Cells {
+		for i := 0; i < cell.mineCount(); i++ {
+			cells = append(cells, cellIndex)
+		}
+	}
+	return cells
+}
+
+// layMines lays the mines of mineCells on a board whose mines are pending
+func (board *Board) layMines(cells []int) {
+	for _, cellIndex := range cells {
+		cell := board.Cells[cellIndex]
+		if cell.IsMined && board.MinesPerCell() > 1 {
+			cell.Mines++
+			continue
+		}
+		cell.IsMined = true
+		if board.MinesPerCell() > 1 {
+			cell.Mines = 1
+		}
+	}
+	board.countMinesAround()
+	board.PendingMines = false
+}
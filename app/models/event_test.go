@@ -0,0 +1,105 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newEventGame(seed int64) *Game {
+	game := newSeededGame(9, 9, 10, FirstClickSafeOpening, seed)
+	game.Board.NoGuess = true
+	game.UndoLimit = 3
+	game.ApplyEvent(game.NewEvent(EventCreated, at(0)), nil)
+	return game
+}
+
+func TestGame_ApplyEvent_Replay(t *testing.T) {
+	game := newEventGame(1)
+	var events []*GameEvent
+	play := func(eventType EventType, action GameAction, cell *CellRequest, seconds int) {
+		event := game.NewEvent(eventType, at(seconds))
+		event.Action = action
+		event.Cell = cell
+		assert.NoError(t, game.ApplyEvent(event, nil))
+		events = append(events, event)
+	}
+
+	play(EventUncovered, "", &CellRequest{Row: 5, Column: 5}, 1)
+	assert.NotNil(t, events[0].MineLayout)
+	mined := game.Board.Cells[minedCells(game.Board)[0]]
+	position := game.Board.cellPosition(minedCells(game.Board)[0])
+	play(EventFlagged, ActionCycleMark, position, 2)
+	play(EventPaused, "", nil, 3)
+	play(EventResumed, "", nil, 10)
+	play(EventFlagged, ActionMarkQuestion, &CellRequest{Row: 1, Column: 1}, 11)
+	play(EventUndone, "", nil, 12)
+	assert.Equal(t, FlagRed, mined.Flag)
+
+	// the mines come from the event, a board drawn from another seed ends up the same
+	replayed := newEventGame(2)
+	for _, event := range events {
+		assert.NoError(t, replayed.ApplyEvent(event, nil))
+	}
+
+	assert.Equal(t, game.Board.Cells, replayed.Board.Cells)
+	assert.Equal(t, game.Board.OpenCells, replayed.Board.OpenCells)
+	assert.Equal(t, game.Version, replayed.Version)
+	assert.Equal(t, game.ElapsedMs, replayed.ElapsedMs)
+	assert.Equal(t, int64(4000), replayed.ElapsedMs)
+	assert.Equal(t, game.UndosUsed, replayed.UndosUsed)
+	assert.Len(t, replayed.Undone, 1)
+}
+
+func TestGame_ApplyEvent_Errors(t *testing.T) {
+	game := newEventGame(1)
+
+	skipped := game.NewEvent(EventPaused, at(1))
+	skipped.Seq++
+	assert.Error(t, game.ApplyEvent(skipped, nil))
+
+	game.ApplyEvent(game.NewEvent(EventPaused, at(1)), nil)
+	err := game.ApplyEvent(game.NewEvent(EventUncovered, at(2)), nil)
+	assert.IsType(t, &TransitionError{}, err)
+	assert.Equal(t, 2, game.Version)
+
+	unknown := game.NewEvent(EventFlagged, at(3))
+	unknown.Action = ActionUncover
+	assert.Error(t, game.ApplyEvent(unknown, nil))
+}
+
+func TestGame_ApplyEvent_Timeout(t *testing.T) {
+	game := newEventGame(1)
+	game.TimeLimitMs = 5000
+	game.ApplyEvent(&GameEvent{Seq: 2, Type: EventUncovered, At: at(1), Cell: &CellRequest{Row: 5, Column: 5}}, nil)
+
+	lost := game.NewEvent(EventLost, at(30))
+	lost.LossReason = LossReasonTimeout
+	assert.NoError(t, game.ApplyEvent(lost, nil))
+
+	assert.Equal(t, Lose, game.State)
+	assert.Equal(t, at(6), *game.EndedAt)
+	assert.Equal(t, int64(5000), game.ElapsedMs)
+}
+
+func TestGame_SnapshotDue(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int
+		previous StateGame
+		state    StateGame
+		appended int
+		want     bool
+	}{
+		{name: "Between snapshots", version: 13, previous: Playing, state: Playing, appended: 1},
+		{name: "On the interval", version: 20, previous: Playing, state: Playing, appended: 1, want: true},
+		{name: "Past the interval with the end of the game", version: 21, previous: Playing, state: Playing, appended: 2, want: true},
+		{name: "State changed", version: 7, previous: Playing, state: Paused, appended: 1, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := &Game{State: tt.state, Version: tt.version}
+
+			assert.Equal(t, tt.want, game.SnapshotDue(tt.previous, tt.appended, 10))
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package models
 
 import (
-	"fmt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"math/rand"
 	"time"
@@ -20,226 +19,374 @@ type GameDto struct {
 	Data []*Game `json:"data"`
 }
 
+type FirstClick string
+
+const (
+	FirstClickOff         FirstClick = "off"
+	FirstClickSafeCell    FirstClick = "safe-cell"
+	FirstClickSafeOpening FirstClick = "safe-opening"
+)
+
 type NewGameRequest struct {
-	Rows    int `json:"rows"`
-	Columns int `json:"columns"`
-	Mines   int `json:"mines"`
+	Rows       int          `json:"rows"`
+	Columns    int          `json:"columns"`
+	Mines      int          `json:"mines"`
+	FirstClick FirstClick   `json:"firstClick"`
+	Topology   TopologyType `json:"topology"`
+	Layout     LayoutType   `json:"layout"`
+	Layers     int          `json:"layers"`
+	Mask       string       `json:"mask,omitempty"`
+	Endless    bool         `json:"endless"`
+	Mode       GameModeType `json:"mode"`
+	Lives      int          `json:"lives,omitempty"`
+	NoGuess    bool         `json:"noGuess"`
+	Seed       int64        `json:"seed,string,omitempty"`
+	// MaxMinesPerCell is the number of mines a cell may hold on multi-mine boards
+	MaxMinesPerCell int `json:"maxMinesPerCell,omitempty"`
+	// TimeLimit makes a timed game, in seconds of time played
+	TimeLimit int `json:"timeLimit,omitempty"`
 }
 
+// CellRequest is the position of a cell, the layer is only needed on 3D boards
 type CellRequest struct {
+	Layer  int `json:"layer,omitempty"`
 	Row    int `json:"row"`
-	Column int `json:"column"`}
+	Column int `json:"column"`
+	// Count is the number of mines a red flag claims on multi-mine boards
+	Count int `json:"count,omitempty"`
+}
 
 type Game struct {
-	Id         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Board      *Board             `bson:"board" json:"board"`
-	UserName   string             `bson:"user_name" json:"userName"`
-	State      StateGame          `bson:"state" json:"state"`
-	CreationAt time.Time          `bson:"creation_at" json:"createAt,omitempty"`
-	EndedAt    *time.Time         `bson:"ended_at" json:"endedAt, omitempty"`
+	Id       primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Board    *Board             `bson:"board" json:"board"`
+	UserName string             `bson:"user_name" json:"userName"`
+	State    StateGame          `bson:"state" json:"state"`
+	Seed     int64              `bson:"seed" json:"seed,string"`
+	// NoGuessFallback is set when no board solvable without guessing was found in time and a normal one was used
+	NoGuessFallback bool       `bson:"no_guess_fallback" json:"noGuessFallback"`
+	HintsUsed       int        `bson:"hints_used" json:"hintsUsed"`
+	CreationAt      time.Time  `bson:"creation_at" json:"createAt,omitempty"`
+	EndedAt         *time.Time `bson:"ended_at" json:"endedAt,omitempty"`
+	// Endless is set instead of the board on endless games
+	Endless *EndlessBoard `bson:"endless,omitempty" json:"endless,omitempty"`
+	// Lives are the mines a practice game may still uncover, ExplodedMines the mines uncovered so far
+	Lives         int `bson:"lives" json:"lives"`
+	ExplodedMines int `bson:"exploded_mines" json:"explodedMines"`
+	// Ranked games go to the leaderboards, which keep each mode apart. Practice games are not ranked.
+	Ranked bool `bson:"ranked" json:"ranked"`
+	// StartedAt is the first move, the clock runs from it to EndedAt except for the pauses
+	StartedAt *time.Time `bson:"started_at" json:"startedAt,omitempty"`
+	Pauses    []*Pause   `bson:"pauses" json:"pauses,omitempty"`
+	// ElapsedMs is the time played in milliseconds, it is set by UpdateClock every time the game is returned
+	ElapsedMs int64 `bson:"-" json:"elapsedMs"`
+	// TimeLimitMs is the time a timed game may be played for, once it is over the game is lost
+	TimeLimitMs int64      `bson:"time_limit_ms" json:"timeLimitMs,omitempty"`
+	LossReason  LossReason `bson:"loss_reason" json:"lossReason,omitempty"`
+	// Moves are the moves that can be undone, the last one first, and Undone the ones that can be redone.
+	// UndoLimit is the number of undos the game may use, it comes from the mode when the game is created.
+	Moves     []*Move `bson:"moves" json:"-"`
+	Undone    []*Move `bson:"undone" json:"-"`
+	UndoLimit int     `bson:"undo_limit" json:"undoLimit"`
+	UndosUsed int     `bson:"undos_used" json:"undosUsed"`
+	// autoFlagged are the cells the last winning move flagged as they were before, for the move to be undone
+	autoFlagged []*CellSnapshot
+	// Version is the Seq of the last event applied, the stored game is a snapshot of the game at that event
+	Version int `bson:"version" json:"version"`
 }
 
 type Board struct {
-	Rows    int     `bson:"rows" json:"rows"`
-	Columns int     `bson:"columns" json:"columns"`
-	Mines   int     `bson:"mines" json:"mines"`
-	OpenCells int     `bson:"open_cells"`
-	Cells   []*Cell `bson:"cells" json:"cells"`
+	Rows         int          `bson:"rows" json:"rows"`
+	Columns      int          `bson:"columns" json:"columns"`
+	Mines        int          `bson:"mines" json:"mines"`
+	OpenCells    int          `bson:"open_cells"`
+	FirstClick   FirstClick   `bson:"first_click" json:"firstClick"`
+	Topology     TopologyType `bson:"topology" json:"topology"`
+	Layout       LayoutType   `bson:"layout" json:"layout"`
+	PendingMines bool         `bson:"pending_mines" json:"pendingMines"`
+	NoGuess      bool         `bson:"no_guess" json:"noGuess"`
+	// NoGuessAttempts is the number of layouts drawn until one was solvable or the budget ran out
+	NoGuessAttempts int `bson:"no_guess_attempts" json:"noGuessAttempts"`
+	// Layers is the depth of 3D boards, the cells are stored layer after layer and row after row.
+	// Boards saved before 3D boards existed have no layers and are a single layer.
+	Layers int `bson:"layers" json:"layers,omitempty"`
+	// Mask is the shape the board was made from, see ParseMask. Voids is the number of cells it leaves out.
+	Mask            string       `bson:"mask" json:"mask,omitempty"`
+	Voids           int          `bson:"voids" json:"voids,omitempty"`
+	Mode            GameModeType `bson:"mode" json:"mode,omitempty"`
+	MaxMinesPerCell int          `bson:"max_mines_per_cell" json:"maxMinesPerCell,omitempty"`
+	// MinesRemaining is the mines less the red flags, it is counted again every time the board is loaded
+	MinesRemaining int     `bson:"-" json:"minesRemaining"`
+	Cells          []*Cell `bson:"cells" json:"cells"`
 }
 
 type Cell struct {
-	IsMined      bool `bson:"is_mined" json:"isMined"`
-	MinesAround  int  `bson:"mines_around" json:"minesAround"`
-	RedFlag      bool `bson:"red_flag" json:"redFlag"`
-	QuestionFlag bool `bson:"question_flag" json:"questionFlag"`
-	IsOpen       bool `bson:"is:_open" json:"isOpen"`
+	IsMined     bool     `bson:"is_mined" json:"isMined"`
+	MinesAround int      `bson:"mines_around" json:"minesAround"`
+	Flag        FlagType `bson:"flag" json:"flag"`
+	IsOpen      bool     `bson:"is:_open" json:"isOpen"`
+	// Void cells are outside the shape of the board, they never hold mines and touch no other cell
+	Void bool `bson:"void" json:"void,omitempty"`
+	// Mines is the number of mines on the cell on multi-mine boards, FlagCount the number its red flag claims
+	Mines     int `bson:"mines" json:"mines,omitempty"`
+	FlagCount int `bson:"flag_count" json:"flagCount,omitempty"`
+	// Exploded is a mine the player uncovered, it stays covered and is known to hold a mine
+	Exploded bool `bson:"exploded" json:"exploded,omitempty"`
+	// AutoFlagged is a mine flagged when the game was won, the player had not flagged it
+	AutoFlagged bool `bson:"auto_flagged,omitempty" json:"-"`
+	// LegacyRedFlag and LegacyQuestionFlag are the flags of cells saved before Flag, see MigrateFlags
+	LegacyRedFlag      bool `bson:"red_flag,omitempty" json:"-"`
+	LegacyQuestionFlag bool `bson:"question_flag,omitempty" json:"-"`
 }
 
-func (game *Game) UncoverCell(row int, column int) {
-	minedCellIndex := game.Board.calculateCell(row, column)
-	if game.Board.Cells[minedCellIndex].IsMined {
-		game.State = Lose
-		return
+// UncoverCell opens the cell following the mode of the board and returns every cell it opened, in order
+func (game *Game) UncoverCell(cell *CellRequest) []int {
+	minedCellIndex := game.Board.calculateCell(cell)
+	// a red flag keeps the cell from being uncovered by mistake, it has to be taken off first
+	if game.Board.Cells[minedCellIndex].Flag == FlagRed {
+		return nil
 	}
-	if !game.Board.Cells[minedCellIndex].IsOpen {
-		game.Board.Cells[minedCellIndex].IsOpen = true
-		game.Board.OpenCells = game.Board.OpenCells + 1
-
-		game.recursivelyUncover(minedCellIndex, true)
-		if game.Board.OpenCells + game.Board.Mines == game.Board.Rows*game.Board.Columns {
-			game.State = Won
-		}
+	if game.Board.PendingMines {
+		// the mines are laid on this move, so there is no board to go back to
+		game.fillMines(minedCellIndex)
+		game.forgetMoves()
+		return game.uncover(minedCellIndex)
 	}
+	return game.record(ActionUncover, minedCellIndex, func() []int {
+		return game.uncover(minedCellIndex)
+	})
 }
 
-func (game *Game) recursivelyUncover(minedCellIndex int, firsTime bool) {
-	if game.Board.Cells[minedCellIndex].IsOpen == false || firsTime {
-		if game.Board.Cells[minedCellIndex].MinesAround == 0 {
-			game.Board.Cells[minedCellIndex].IsOpen = true
-			if !firsTime {
-				game.Board.OpenCells = game.Board.OpenCells + 1
-			}
-			isNotOnLeftEdge := game.Board.isNotOnLeftEdge(minedCellIndex)
-			isNotOnRightEdge := game.Board.isNotOnRightEdge(minedCellIndex)
-			isNotOnTopEdge := game.Board.isNotOnTopEdge(minedCellIndex)
-			isNotOnBottomEdge := game.Board.isNotOnBottomEdge(minedCellIndex)
-
-			if isNotOnLeftEdge {
-				left := minedCellIndex - 1
-				game.recursivelyUncover(left, false)
-			}
-
-			if isNotOnRightEdge {
-				right := minedCellIndex + 1
-				game.recursivelyUncover(right, false)
-			}
+// NoGuessBudget is the time a no guess board generation may take before falling back to a normal board
+var NoGuessBudget = 2 * time.Second
 
-			if isNotOnTopEdge {
-				up := minedCellIndex - game.Board.Columns
-				game.recursivelyUncover(up, false)
-			}
+// MaxNoGuessCells is the largest board, layers included, a no guess board is generated for.
+// Checking a layout takes longer the larger the board is, so larger boards would rarely finish in the budget.
+const MaxNoGuessCells = 100 * 100
 
-			if isNotOnBottomEdge {
-				bottom := minedCellIndex + game.Board.Columns
-				game.recursivelyUncover(bottom, false)
-			}
+func (game *Game) fillMines(firstCellIndex int) {
+	random := game.NewRandom()
+	if !game.Board.NoGuess {
+		game.Board.fillMinesAvoiding(random, firstCellIndex)
+		return
+	}
+	game.NoGuessFallback = !game.Board.fillNoGuessMines(random, firstCellIndex, time.Now().Add(NoGuessBudget))
+}
 
-			if isNotOnLeftEdge && isNotOnTopEdge {
-				leftUp := minedCellIndex - 1 - game.Board.Columns
-				game.recursivelyUncover(leftUp, false)
-			}
+// ChordCell uncovers every unflagged neighbour of an open number once it has as many red flags around as mines
+func (game *Game) ChordCell(position *CellRequest) []int {
+	cellIndex := game.Board.calculateCell(position)
+	cell := game.Board.Cells[cellIndex]
+	if !cell.IsOpen || cell.MinesAround == 0 {
+		return nil
+	}
 
-			if isNotOnRightEdge && isNotOnTopEdge {
-				rightUp := minedCellIndex + 1 - game.Board.Columns
-				game.recursivelyUncover(rightUp, false)
-			}
+	adjacentCells := game.Board.adjacentCells(cellIndex)
+	redFlags := 0
+	for _, adjacent := range adjacentCells {
+		redFlags = redFlags + game.Board.Cells[adjacent].flaggedMines()
+	}
+	if redFlags != cell.MinesAround {
+		return nil
+	}
 
-			if isNotOnLeftEdge && isNotOnBottomEdge {
-				leftBottom := minedCellIndex - 1 + game.Board.Columns
-				game.recursivelyUncover(leftBottom, false)
+	return game.record(ActionChord, cellIndex, func() []int {
+		var openedCells []int
+		for _, adjacent := range adjacentCells {
+			if game.Board.Cells[adjacent].Flag == FlagRed || game.Board.Cells[adjacent].IsOpen {
+				continue
 			}
-
-			if isNotOnRightEdge && isNotOnBottomEdge {
-				rightBottom := minedCellIndex + 1 + game.Board.Columns
-				game.recursivelyUncover(rightBottom, false)
+			openedCells = append(openedCells, game.uncover(adjacent)...)
+			if game.State != Playing {
+				break
 			}
 		}
-	}
+		return openedCells
+	})
 }
 
-func (board *Board) InitBoard() {
-	board.fillEmptyCellsToBoard()
-	board.fillMinesToBoard()
+func (game *Game) uncover(cellIndex int) []int {
+	openedCells := game.Board.mode().Uncover(game, cellIndex)
+	game.evaluate()
+	return openedCells
 }
 
-func (board *Board) fillEmptyCellsToBoard() {
-	for i := 0; i < board.Rows*board.Columns; i++ {
-		board.Cells[i] = &Cell{
-			IsMined:      false,
-			MinesAround:  0,
-			RedFlag:      false,
-			QuestionFlag: false,
-			IsOpen:       false,
+// evaluate lets the mode decide the state of the game after a move, only a game in play may change.
+// Every mine of a won game is flagged.
+func (game *Game) evaluate() {
+	if game.State == Playing {
+		game.State = game.Board.mode().Evaluate(game)
+		if game.State == Lose {
+			game.LossReason = LossReasonMine
+		}
+		if game.State == Won {
+			game.autoFlagged = game.Board.flagMines()
 		}
 	}
 }
 
-func (board *Board) fillMinesToBoard() {
-	for i := 0; i < board.Mines; i++ {
-		minedCellIndex := board.getRandomValueWithoutMine()
-		board.fillMine(minedCellIndex)
+// Reveal opens the cell and floods through the cells without mines around, returning the opened cells in order.
+// It uses the opened cells as a queue, so every cell is visited once whatever the size of the region.
+func (board *Board) Reveal(cellIndex int) []int {
+	if board.Cells[cellIndex].IsOpen {
+		return nil
 	}
-}
-
-func (board *Board) fillMine(minedCellIndex int) {
-	board.Cells[minedCellIndex].IsMined = true
-	board.sumMinesAroundToAdjacent(minedCellIndex)
-}
-
-func (board *Board) sumMinesAroundToAdjacent(minedCellIndex int) {
-	isNotOnLeftEdge := board.isNotOnLeftEdge(minedCellIndex)
-	isNotOnRightEdge := board.isNotOnRightEdge(minedCellIndex)
-	isNotOnTopEdge := board.isNotOnTopEdge(minedCellIndex)
-	isNotOnBottomEdge := board.isNotOnBottomEdge(minedCellIndex)
 
-	if isNotOnLeftEdge {
-		left := minedCellIndex - 1
-		board.Cells[left].MinesAround = board.Cells[left].MinesAround + 1
+	board.Cells[cellIndex].IsOpen = true
+	openedCells := []int{cellIndex}
+	adjacentCells := make([]int, 0, 26)
+	for next := 0; next < len(openedCells); next++ {
+		current := openedCells[next]
+		if board.Cells[current].MinesAround != 0 {
+			continue
+		}
+		adjacentCells = board.appendAdjacentCells(adjacentCells[:0], current)
+		for _, adjacent := range adjacentCells {
+			// a red flag keeps the cell covered, the flood goes around it as a chord does
+			if board.Cells[adjacent].IsOpen || board.Cells[adjacent].IsMined || board.Cells[adjacent].Flag == FlagRed {
+				continue
+			}
+			board.Cells[adjacent].IsOpen = true
+			openedCells = append(openedCells, adjacent)
+		}
 	}
+	board.OpenCells = board.OpenCells + len(openedCells)
+	return openedCells
+}
 
-	if isNotOnRightEdge {
-		right := minedCellIndex + 1
-		board.Cells[right].MinesAround = board.Cells[right].MinesAround + 1
-	}
+// NewRandom returns the random source of the game, the same seed always places the same mines
+func (game *Game) NewRandom() *rand.Rand {
+	return rand.New(rand.NewSource(game.Seed))
+}
 
-	if isNotOnTopEdge {
-		up := minedCellIndex - board.Columns
-		board.Cells[up].MinesAround = board.Cells[up].MinesAround + 1
+func (board *Board) InitBoard(random *rand.Rand) {
+	board.fillEmptyCellsToBoard()
+	board.fillVoids()
+	board.MinesRemaining = board.Mines
+	if board.FirstClick == FirstClickOff {
+		board.fillMinesToBoard(random, nil)
+		return
 	}
+	// the mines are placed on the first uncover, so the clicked cell can be kept safe
+	board.PendingMines = true
+}
 
-	if isNotOnBottomEdge {
-		bottom := minedCellIndex + board.Columns
-		board.Cells[bottom].MinesAround = board.Cells[bottom].MinesAround + 1
+func (board *Board) fillEmptyCellsToBoard() {
+	for i := range board.Cells {
+		board.Cells[i] = &Cell{
+			IsMined:     false,
+			MinesAround: 0,
+			Flag:        FlagNone,
+			IsOpen:      false,
+		}
 	}
+}
 
-	if isNotOnLeftEdge && isNotOnTopEdge {
-		leftUp := minedCellIndex - 1 - board.Columns
-		board.Cells[leftUp].MinesAround = board.Cells[leftUp].MinesAround + 1
+// fillMinesToBoard draws the mines with a partial Fisher–Yates shuffle over the cells that may hold one,
+// so the cost is the same whatever the density of the board
+func (board *Board) fillMinesToBoard(random *rand.Rand, safeCells map[int]bool) {
+	candidates := make([]int, 0, len(board.Cells))
+	for cellIndex := range board.Cells {
+		if !safeCells[cellIndex] && !board.isVoid(cellIndex) {
+			candidates = append(candidates, cellIndex)
+		}
 	}
 
-	if isNotOnRightEdge && isNotOnTopEdge {
-		rightUp := minedCellIndex + 1 - board.Columns
-		board.Cells[rightUp].MinesAround = board.Cells[rightUp].MinesAround + 1
-	}
+	board.mode().PlaceMines(board, random, candidates)
+	board.countMinesAround()
+}
 
-	if isNotOnLeftEdge && isNotOnBottomEdge {
-		leftBottom := minedCellIndex - 1 + board.Columns
-		board.Cells[leftBottom].MinesAround = board.Cells[leftBottom].MinesAround + 1
-	}
+func (board *Board) fillMinesAvoiding(random *rand.Rand, firstCellIndex int) {
+	board.fillMinesToBoard(random, board.safeCells(firstCellIndex))
+	board.PendingMines = false
+}
 
-	if isNotOnRightEdge && isNotOnBottomEdge {
-		rightBottom := minedCellIndex + 1 + board.Columns
-		board.Cells[rightBottom].MinesAround = board.Cells[rightBottom].MinesAround + 1
+// fillNoGuessMines draws layouts until the solver clears one from the first cell without guessing.
+// When the deadline passes it keeps the last layout, a normal board, and returns false.
+func (board *Board) fillNoGuessMines(random *rand.Rand, firstCellIndex int, deadline time.Time) bool {
+	safeCells := board.safeCells(firstCellIndex)
+	board.PendingMines = false
+	for {
+		board.NoGuessAttempts++
+		for _, cell := range board.Cells {
+			cell.IsMined = false
+			cell.Mines = 0
+		}
+		board.fillMinesToBoard(random, safeCells)
+		if board.solvableFrom(firstCellIndex, deadline) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
 	}
 }
 
-func (board *Board) isNotOnLeftEdge(minedCellIndex int) bool {
-	return minedCellIndex%board.Columns != 0
+func (board *Board) safeCells(firstCellIndex int) map[int]bool {
+	safeCells := map[int]bool{firstCellIndex: true}
+	if board.FirstClick != FirstClickSafeCell {
+		adjacentCells := board.adjacentCells(firstCellIndex)
+		// on crowded boards there is no room for a whole opening, only the clicked cell is kept safe
+		if (board.playableCells()-len(adjacentCells)-1)*board.MinesPerCell() >= board.Mines {
+			for _, adjacent := range adjacentCells {
+				safeCells[adjacent] = true
+			}
+		}
+	}
+	return safeCells
 }
 
-func (board *Board) isNotOnRightEdge(minedCellIndex int) bool {
-	return (minedCellIndex+1)%board.Columns != 0
+func (board *Board) clone() *Board {
+	clone := *board
+	clone.Cells = make([]*Cell, len(board.Cells))
+	for cellIndex, cell := range board.Cells {
+		cellCopy := *cell
+		clone.Cells[cellIndex] = &cellCopy
+	}
+	return &clone
 }
 
-func (board *Board) isNotOnTopEdge(minedCellIndex int) bool {
-	return minedCellIndex/board.Columns != 0
+func (board *Board) countMinesAround() {
+	adjacentCells := make([]int, 0, 26)
+	for cellIndex, cell := range board.Cells {
+		cell.MinesAround = 0
+		adjacentCells = board.appendAdjacentCells(adjacentCells[:0], cellIndex)
+		for _, adjacent := range adjacentCells {
+			cell.MinesAround = cell.MinesAround + board.Cells[adjacent].mineCount()
+		}
+	}
 }
 
-func (board *Board) isNotOnBottomEdge(minedCellIndex int) bool {
-	return minedCellIndex/board.Columns < board.Rows-1
+// calculateCell returns the index of the cell, the positions count from one and a missing layer is the first one
+func (board *Board) calculateCell(cell *CellRequest) int {
+	layer := cell.Layer
+	if layer == 0 {
+		layer = 1
+	}
+	return board.cellIndex(layer-1, cell.Row-1, cell.Column-1)
 }
 
-func (board *Board) getRandomValueWithoutMine() int {
-	foundRandom := false
-	random := 0
-	for foundRandom != true {
-		random = rand.Intn(board.Rows * board.Columns)
-		fmt.Print(random)
-		if !board.Cells[random].IsMined {
-			foundRandom = true
-		}
+// cellPosition is the opposite of calculateCell, the layer is left out on flat boards
+func (board *Board) cellPosition(cellIndex int) *CellRequest {
+	layer, row, column := board.cellCoordinates(cellIndex)
+	position := &CellRequest{Row: row + 1, Column: column + 1}
+	if board.layerCount() > 1 {
+		position.Layer = layer + 1
 	}
-	return random
+	return position
 }
 
-func (board *Board) MarkRed(row int, column int) {
-	board.Cells[board.calculateCell(row, column)].RedFlag = true
+func (board *Board) cellIndex(layer int, row int, column int) int {
+	return (layer*board.Rows+row)*board.Columns + column
 }
 
-func (board *Board) MarkQuestion(row int, column int) {
-	board.Cells[board.calculateCell(row, column)].QuestionFlag = true
+func (board *Board) cellCoordinates(cellIndex int) (int, int, int) {
+	layerSize := board.Rows * board.Columns
+	return cellIndex / layerSize, cellIndex % layerSize / board.Columns, cellIndex % board.Columns
 }
 
-func (board *Board) calculateCell(row int, column int) int {
-	return ((row - 1)* board.Columns) + column - 1
-}
\ No newline at end of file
+func (board *Board) layerCount() int {
+	if board.Layers < 1 {
+		return 1
+	}
+	return board.Layers
+}
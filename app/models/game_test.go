@@ -0,0 +1,266 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func newTestGame(rows int, columns int, mines int, firstClick FirstClick) *Game {
+	return newSeededGame(rows, columns, mines, firstClick, 1)
+}
+
+func newSeededGame(rows int, columns int, mines int, firstClick FirstClick, seed int64) *Game {
+	game := &Game{State: Playing, Seed: seed}
+	game.Board = &Board{
+		Rows:       rows,
+		Columns:    columns,
+		Mines:      mines,
+		FirstClick: firstClick,
+		Cells:      make([]*Cell, rows*columns),
+	}
+	game.Board.InitBoard(game.NewRandom())
+	return game
+}
+
+func minedCells(board *Board) []int {
+	var mines []int
+	for cellIndex, cell := range board.Cells {
+		if cell.IsMined {
+			mines = append(mines, cellIndex)
+		}
+	}
+	return mines
+}
+
+func TestGame_UncoverCell_FirstClick(t *testing.T) {
+	tests := []struct {
+		name       string
+		rows       int
+		columns    int
+		mines      int
+		firstClick FirstClick
+		assert     func(*testing.T, *Game)
+	}{
+		{
+			name:       "Success - safe opening keeps the neighbours free of mines",
+			rows:       9,
+			columns:    9,
+			mines:      40,
+			firstClick: FirstClickSafeOpening,
+			assert: func(t *testing.T, game *Game) {
+				assert.NotEqual(t, Lose, game.State)
+				assert.Equal(t, 0, game.Board.Cells[game.Board.calculateCell(&CellRequest{Row: 5, Column: 5})].MinesAround)
+			},
+		},
+		{
+			name:       "Success - safe cell only keeps the clicked cell free of mines",
+			rows:       3,
+			columns:    3,
+			mines:      8,
+			firstClick: FirstClickSafeCell,
+			assert: func(t *testing.T, game *Game) {
+				assert.Equal(t, Won, game.State)
+				assert.Equal(t, 8, game.Board.Cells[game.Board.calculateCell(&CellRequest{Row: 2, Column: 2})].MinesAround)
+			},
+		},
+		{
+			name:       "Success - crowded board falls back to a safe cell",
+			rows:       3,
+			columns:    3,
+			mines:      7,
+			firstClick: FirstClickSafeOpening,
+			assert: func(t *testing.T, game *Game) {
+				assert.NotEqual(t, Lose, game.State)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(tt.rows, tt.columns, tt.mines, tt.firstClick)
+			assert.True(t, game.Board.PendingMines)
+
+			row, column := (tt.rows+1)/2, (tt.columns+1)/2
+			game.UncoverCell(&CellRequest{Row: row, Column: column})
+
+			assert.False(t, game.Board.PendingMines)
+			assert.Len(t, minedCells(game.Board), tt.mines)
+			assert.False(t, game.Board.Cells[game.Board.calculateCell(&CellRequest{Row: row, Column: column})].IsMined)
+			tt.assert(t, game)
+		})
+	}
+}
+
+func TestBoard_InitBoard_FirstClickOff(t *testing.T) {
+	game := newTestGame(5, 5, 10, FirstClickOff)
+
+	assert.False(t, game.Board.PendingMines)
+	assert.Len(t, minedCells(game.Board), 10)
+}
+
+func TestGame_Seed(t *testing.T) {
+	tests := []struct {
+		name       string
+		firstClick FirstClick
+	}{
+		{name: "Success - mines placed on creation", firstClick: FirstClickOff},
+		{name: "Success - mines placed on the first uncover", firstClick: FirstClickSafeOpening},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newSeededGame(16, 30, 99, tt.firstClick, 42)
+			sameSeed := newSeededGame(16, 30, 99, tt.firstClick, 42)
+			otherSeed := newSeededGame(16, 30, 99, tt.firstClick, 43)
+			for _, g := range []*Game{game, sameSeed, otherSeed} {
+				g.UncoverCell(&CellRequest{Row: 8, Column: 15})
+			}
+
+			assert.Equal(t, minedCells(game.Board), minedCells(sameSeed.Board))
+			assert.NotEqual(t, minedCells(game.Board), minedCells(otherSeed.Board))
+		})
+	}
+}
+
+func newMinedGame(rows int, columns int, mines ...int) *Game {
+	board := &Board{
+		Rows:       rows,
+		Columns:    columns,
+		Mines:      len(mines),
+		FirstClick: FirstClickOff,
+		Cells:      make([]*Cell, rows*columns),
+	}
+	board.fillEmptyCellsToBoard()
+	for _, mine := range mines {
+		board.Cells[mine].IsMined = true
+	}
+	board.countMinesAround()
+	return &Game{Board: board, State: Playing}
+}
+
+func TestGame_ChordCell(t *testing.T) {
+	tests := []struct {
+		name      string
+		redFlags  []int
+		wantState StateGame
+		wantOpen  []int
+	}{
+		{
+			name:      "Success - satisfied number uncovers the unflagged neighbours",
+			redFlags:  []int{0},
+			wantState: Playing,
+			wantOpen:  []int{1, 2, 3, 5, 6, 7, 8},
+		},
+		{
+			name:      "Success - unsatisfied number does nothing",
+			redFlags:  []int{},
+			wantState: Playing,
+			wantOpen:  []int{4},
+		},
+		{
+			name:      "Error - wrong flag uncovers the mine",
+			redFlags:  []int{1},
+			wantState: Lose,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 4x3 board with mines on the top left corner and the bottom row
+			game := newMinedGame(4, 3, 0, 10)
+			game.UncoverCell(&CellRequest{Row: 2, Column: 2})
+			for _, redFlag := range tt.redFlags {
+				game.Board.Cells[redFlag].Flag = FlagRed
+			}
+
+			game.ChordCell(&CellRequest{Row: 2, Column: 2})
+
+			assert.Equal(t, tt.wantState, game.State)
+			for _, open := range tt.wantOpen {
+				assert.True(t, game.Board.Cells[open].IsOpen)
+			}
+		})
+	}
+}
+
+func TestBoard_Reveal(t *testing.T) {
+	// 3x5 board with a single mine on the right edge:
+	// 0 0 0 1 .
+	// 0 0 0 1 *
+	// 0 0 0 1 .
+	game := newMinedGame(3, 5, 9)
+
+	openedCells := game.Board.Reveal(0)
+
+	assert.Equal(t, []int{0, 1, 5, 6, 2, 7, 10, 11, 12, 3, 8, 13}, openedCells)
+	assert.Equal(t, 12, game.Board.OpenCells)
+	assert.False(t, game.Board.Cells[4].IsOpen)
+	assert.False(t, game.Board.Cells[14].IsOpen)
+	assert.Nil(t, game.Board.Reveal(0))
+}
+
+func benchmarkReveal(b *testing.B, rows int, columns int, mines int) {
+	game := newMinedGame(rows, columns)
+	game.Board.Mines = mines
+	game.Board.fillMinesToBoard(rand.New(rand.NewSource(1)), map[int]bool{0: true})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		for _, cell := range game.Board.Cells {
+			cell.IsOpen = false
+		}
+		game.Board.OpenCells = 0
+		b.StartTimer()
+
+		game.Board.Reveal(0)
+	}
+}
+
+func BenchmarkBoard_Reveal_100x100(b *testing.B) {
+	benchmarkReveal(b, 100, 100, 0)
+}
+
+func BenchmarkBoard_Reveal_1000x1000(b *testing.B) {
+	benchmarkReveal(b, 1000, 1000, 0)
+}
+
+func BenchmarkBoard_Reveal_1000x1000_SparseMines(b *testing.B) {
+	benchmarkReveal(b, 1000, 1000, 10000)
+}
+
+func TestBoard_fillMinesToBoard(t *testing.T) {
+	tests := []struct {
+		name      string
+		mines     int
+		safeCells map[int]bool
+	}{
+		{name: "Success - low density", mines: 10},
+		{name: "Success - every cell but one", mines: 99},
+		{name: "Success - every cell but the safe ones", mines: 91, safeCells: map[int]bool{0: true, 1: true, 10: true, 11: true, 55: true, 56: true, 57: true, 98: true, 99: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newMinedGame(10, 10)
+			game.Board.Mines = tt.mines
+
+			game.Board.fillMinesToBoard(rand.New(rand.NewSource(7)), tt.safeCells)
+
+			assert.Len(t, minedCells(game.Board), tt.mines)
+			for cellIndex, cell := range game.Board.Cells {
+				assert.False(t, tt.safeCells[cellIndex] && cell.IsMined)
+				minesAround := 0
+				for _, adjacent := range game.Board.adjacentCells(cellIndex) {
+					if game.Board.Cells[adjacent].IsMined {
+						minesAround++
+					}
+				}
+				assert.Equal(t, minesAround, cell.MinesAround)
+			}
+		})
+	}
+}
+
+func BenchmarkBoard_InitBoard_FullDensity(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		newTestGame(1000, 1000, 1000*1000-1, FirstClickOff)
+	}
+}
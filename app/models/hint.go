@@ -0,0 +1,124 @@
+package models
+
+import "fmt"
+
+type HintAction string
+
+const (
+	HintUncover HintAction = "uncover"
+	HintMarkRed HintAction = "mark-red"
+)
+
+// HintConstraint is an open number the hint was deduced from
+type HintConstraint struct {
+	Layer       int `json:"layer,omitempty"`
+	Row         int `json:"row"`
+	Column      int `json:"column"`
+	MinesAround int `json:"minesAround"`
+}
+
+type Hint struct {
+	Found       bool              `json:"found"`
+	Action      HintAction        `json:"action,omitempty"`
+	Layer       int               `json:"layer,omitempty"`
+	Row         int               `json:"row,omitempty"`
+	Column      int               `json:"column,omitempty"`
+	Rule        SolverRule        `json:"rule,omitempty"`
+	Reason      string            `json:"reason"`
+	Constraints []*HintConstraint `json:"constraints,omitempty"`
+}
+
+// Hint returns a move the visible board proves right. Safe cells are preferred, and mines the player
+// already flagged are taken as proved so the next hint moves the game forward.
+func (game *Game) Hint() *Hint {
+	board := game.Board
+	if board.PendingMines {
+		position := board.cellPosition(board.centreCell())
+		return &Hint{
+			Found:  true,
+			Action: HintUncover,
+			Layer:  position.Layer,
+			Row:    position.Row,
+			Column: position.Column,
+			Reason: "the mines are placed after the first uncover, any cell is safe",
+		}
+	}
+
+	s := newSolver(board)
+	for {
+		deductions := s.deduce()
+		if len(deductions) == 0 {
+			return &Hint{Reason: "there is no move the visible board proves safe, a guess is needed"}
+		}
+
+		for _, deduction := range deductions {
+			if !deduction.IsMined {
+				return board.newHint(deduction, HintUncover)
+			}
+		}
+		for _, deduction := range deductions {
+			if board.Cells[deduction.CellIndex].Flag != FlagRed {
+				return board.newHint(deduction, HintMarkRed)
+			}
+		}
+		for _, deduction := range deductions {
+			s.knownMines[deduction.CellIndex] = true
+		}
+	}
+}
+
+// centreCell is the cell in the middle of the board or, when the mask leaves it out, the next cell that is not a void
+func (board *Board) centreCell() int {
+	centre := board.cellIndex((board.layerCount()-1)/2, (board.Rows-1)/2, (board.Columns-1)/2)
+	for i := range board.Cells {
+		cellIndex := (centre + i) % len(board.Cells)
+		if !board.isVoid(cellIndex) {
+			return cellIndex
+		}
+	}
+	return centre
+}
+
+func (board *Board) newHint(deduction *Deduction, action HintAction) *Hint {
+	position := board.cellPosition(deduction.CellIndex)
+	hint := &Hint{
+		Found:  true,
+		Action: action,
+		Layer:  position.Layer,
+		Row:    position.Row,
+		Column: position.Column,
+		Rule:   deduction.Rule,
+	}
+	for _, cellIndex := range deduction.Constraints {
+		constraintPosition := board.cellPosition(cellIndex)
+		hint.Constraints = append(hint.Constraints, &HintConstraint{
+			Layer:       constraintPosition.Layer,
+			Row:         constraintPosition.Row,
+			Column:      constraintPosition.Column,
+			MinesAround: board.Cells[cellIndex].MinesAround,
+		})
+	}
+
+	switch deduction.Rule {
+	case RuleSingle:
+		if deduction.IsMined {
+			hint.Reason = fmt.Sprintf("the %d has as many covered cells around as mines left", hint.Constraints[0].MinesAround)
+		} else {
+			hint.Reason = fmt.Sprintf("the %d already has all its mines around", hint.Constraints[0].MinesAround)
+		}
+	case RulePair:
+		first, second := hint.Constraints[0].MinesAround, hint.Constraints[1].MinesAround
+		if deduction.IsMined {
+			hint.Reason = fmt.Sprintf("the %d leaves room for too few mines of the %d on the cells they share, the rest are on the cells only the %d touches", first, second, second)
+		} else {
+			hint.Reason = fmt.Sprintf("the %d forces every mine of the %d into the cells they share", first, second)
+		}
+	case RuleCount:
+		if deduction.IsMined {
+			hint.Reason = "every covered cell left holds one of the mines left"
+		} else {
+			hint.Reason = "every mine left is already found"
+		}
+	}
+	return hint
+}
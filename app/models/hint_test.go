@@ -0,0 +1,52 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGame_Hint(t *testing.T) {
+	tests := []struct {
+		name     string
+		open     []int
+		redFlags []int
+		want     *Hint
+	}{
+		{
+			// 1 * 1 .
+			// 1 1 1 .
+			name: "Success - proved mine is hinted to be marked",
+			open: []int{0, 4, 5, 6},
+			want: &Hint{Found: true, Action: HintMarkRed, Row: 1, Column: 2, Rule: RuleSingle,
+				Constraints: []*HintConstraint{{Row: 1, Column: 1, MinesAround: 1}}},
+		},
+		{
+			name:     "Success - flagged mine leads to the safe cells around",
+			open:     []int{0, 4, 5, 6},
+			redFlags: []int{1},
+			want: &Hint{Found: true, Action: HintUncover, Row: 1, Column: 3, Rule: RuleSingle,
+				Constraints: []*HintConstraint{{Row: 2, Column: 2, MinesAround: 1}}},
+		},
+		{
+			name: "Success - nothing proved without open cells",
+			want: &Hint{Found: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newMinedGame(2, 4, 1)
+			for _, open := range tt.open {
+				game.Board.Cells[open].IsOpen = true
+			}
+			for _, redFlag := range tt.redFlags {
+				game.Board.Cells[redFlag].Flag = FlagRed
+			}
+
+			hint := game.Hint()
+
+			assert.NotEmpty(t, hint.Reason)
+			hint.Reason = ""
+			assert.Equal(t, tt.want, hint)
+		})
+	}
+}
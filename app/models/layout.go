@@ -0,0 +1,56 @@
+package models
+
+type LayoutType string
+
+const (
+	LayoutSquare LayoutType = "square"
+	LayoutHex    LayoutType = "hex"
+)
+
+// Layout decides the shape of the cells, as the steps from a cell to the cells around it.
+// The topology of the board then decides where the steps that leave the board land.
+type Layout interface {
+	// Offsets are the steps, as row and column, from a cell on the row to the cells around it
+	Offsets(row int) [][2]int
+}
+
+var layouts = map[LayoutType]Layout{
+	LayoutSquare: squareLayout{},
+	LayoutHex:    hexLayout{},
+}
+
+var (
+	squareOffsets = [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}, {-1, -1}, {-1, 1}, {1, -1}, {1, 1}}
+	// hex cells use odd-r offset coordinates, the odd rows are pushed half a cell to the right
+	hexEvenRowOffsets = [][2]int{{0, -1}, {0, 1}, {-1, -1}, {-1, 0}, {1, -1}, {1, 0}}
+	hexOddRowOffsets  = [][2]int{{0, -1}, {0, 1}, {-1, 0}, {-1, 1}, {1, 0}, {1, 1}}
+)
+
+// squareLayout is the classic grid where every cell touches eight others
+type squareLayout struct{}
+
+func (squareLayout) Offsets(row int) [][2]int {
+	return squareOffsets
+}
+
+// hexLayout is a grid of hexagons where every cell touches six others
+type hexLayout struct{}
+
+func (hexLayout) Offsets(row int) [][2]int {
+	if row%2 == 0 {
+		return hexEvenRowOffsets
+	}
+	return hexOddRowOffsets
+}
+
+func (board *Board) layout() Layout {
+	if layout, ok := layouts[board.Layout]; ok {
+		return layout
+	}
+	return squareLayout{}
+}
+
+func IsValidLayout(layout LayoutType) bool {
+	_, ok := layouts[layout]
+	return ok
+}
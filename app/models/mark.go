@@ -0,0 +1,97 @@
+package models
+
+import "errors"
+
+// FlagType is the mark of a cell, a cell holds one mark at a time
+type FlagType string
+
+const (
+	FlagNone     FlagType = "none"
+	FlagRed      FlagType = "red"
+	FlagQuestion FlagType = "question"
+)
+
+// ErrOpenCell is returned when a mark is put on or taken off an open cell
+var ErrOpenCell = errors.New("the cell is open, it can't be marked")
+
+// next is the mark that follows on a cycle: none, red, question and none again
+func (flag FlagType) next() FlagType {
+	switch flag {
+	case FlagRed:
+		return FlagQuestion
+	case FlagQuestion:
+		return FlagNone
+	}
+	return FlagRed
+}
+
+func (game *Game) MarkRed(cell *CellRequest) error {
+	return game.mark(cell, ActionMarkRed, FlagRed)
+}
+
+func (game *Game) MarkQuestion(cell *CellRequest) error {
+	return game.mark(cell, ActionMarkQuestion, FlagQuestion)
+}
+
+// Unmark takes the mark off the cell
+func (game *Game) Unmark(cell *CellRequest) error {
+	return game.mark(cell, ActionUnmark, FlagNone)
+}
+
+// CycleMark moves the cell to its next mark, so a single action goes through every mark
+func (game *Game) CycleMark(cell *CellRequest) error {
+	return game.mark(cell, ActionCycleMark, game.Board.Cells[game.Board.calculateCell(cell)].Flag.next())
+}
+
+// mark flags the cell following the mode of the board, some modes are only won once the mines are flagged
+func (game *Game) mark(cell *CellRequest, action GameAction, flag FlagType) error {
+	cellIndex := game.Board.calculateCell(cell)
+	if game.Board.Cells[cellIndex].IsOpen {
+		return ErrOpenCell
+	}
+	game.record(action, cellIndex, func() []int {
+		game.Board.mode().Mark(game, cellIndex, flag, cell.Count)
+		game.Board.countMinesRemaining()
+		game.evaluate()
+		return nil
+	})
+	return nil
+}
+
+// countMinesRemaining sets MinesRemaining, it goes below zero when the player puts more flags than mines
+func (board *Board) countMinesRemaining() {
+	board.MinesRemaining = board.Mines
+	for _, cell := range board.Cells {
+		board.MinesRemaining -= cell.flaggedMines()
+	}
+}
+
+// MigrateFlags moves the red and question flags of boards saved before Flag to it and counts the mines remaining,
+// it is called every time a game is loaded
+func (game *Game) MigrateFlags() {
+	if game.Board == nil {
+		return
+	}
+	migrateFlags(game.Board.Cells)
+	game.Board.countMinesRemaining()
+}
+
+// MigrateFlags moves the flags of chunks saved before Flag, see Game.MigrateFlags
+func (chunk *Chunk) MigrateFlags() {
+	migrateFlags(chunk.Cells)
+}
+
+func migrateFlags(cells []*Cell) {
+	for _, cell := range cells {
+		if cell == nil || cell.Flag != "" {
+			continue
+		}
+		cell.Flag = FlagNone
+		if cell.LegacyRedFlag {
+			cell.Flag = FlagRed
+		} else if cell.LegacyQuestionFlag {
+			cell.Flag = FlagQuestion
+		}
+		cell.LegacyRedFlag, cell.LegacyQuestionFlag = false, false
+	}
+}
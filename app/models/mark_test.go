@@ -0,0 +1,110 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGame_CycleMark(t *testing.T) {
+	game := newMinedGame(2, 2, 0)
+	game.Board.countMinesRemaining()
+	position := &CellRequest{Row: 1, Column: 1}
+
+	for _, want := range []FlagType{FlagRed, FlagQuestion, FlagNone, FlagRed} {
+		assert.NoError(t, game.CycleMark(position))
+		assert.Equal(t, want, game.Board.Cells[0].Flag)
+	}
+	assert.Equal(t, 0, game.Board.MinesRemaining)
+
+	game.MarkQuestion(position)
+	assert.Equal(t, FlagQuestion, game.Board.Cells[0].Flag)
+	assert.Equal(t, 1, game.Board.MinesRemaining)
+
+	game.Unmark(position)
+	assert.Equal(t, FlagNone, game.Board.Cells[0].Flag)
+}
+
+func TestGame_Mark_MinesRemaining(t *testing.T) {
+	game := newMinedGame(3, 3, 0, 8)
+	game.Board.countMinesRemaining()
+	assert.Equal(t, 2, game.Board.MinesRemaining)
+
+	// a flag on a safe cell counts too, the counter only knows the flags
+	for _, position := range []*CellRequest{{Row: 1, Column: 1}, {Row: 1, Column: 2}, {Row: 1, Column: 3}} {
+		game.MarkRed(position)
+	}
+	assert.Equal(t, -1, game.Board.MinesRemaining)
+
+	game.Unmark(&CellRequest{Row: 1, Column: 2})
+	assert.Equal(t, 0, game.Board.MinesRemaining)
+}
+
+func TestGame_Mark_OpenCell(t *testing.T) {
+	game := newMinedGame(3, 3, 0)
+	game.UncoverCell(&CellRequest{Row: 3, Column: 3})
+
+	assert.Equal(t, ErrOpenCell, game.MarkRed(&CellRequest{Row: 3, Column: 3}))
+	assert.Equal(t, ErrOpenCell, game.CycleMark(&CellRequest{Row: 3, Column: 3}))
+	assert.Equal(t, FlagNone, game.Board.Cells[8].Flag)
+}
+
+func TestGame_UncoverCell_RedFlag(t *testing.T) {
+	game := newMinedGame(3, 3, 0)
+	game.MarkRed(&CellRequest{Row: 1, Column: 1})
+
+	assert.Empty(t, game.UncoverCell(&CellRequest{Row: 1, Column: 1}))
+	assert.Equal(t, Playing, game.State)
+
+	game.MarkQuestion(&CellRequest{Row: 1, Column: 1})
+	game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+	assert.Equal(t, Lose, game.State)
+}
+
+func TestGame_UncoverCell_FloodAroundRedFlag(t *testing.T) {
+	// * . . .
+	// . . . .
+	// . . . .
+	// . . . .
+	game := newMinedGame(4, 4, 0)
+	game.MarkRed(&CellRequest{Row: 3, Column: 3})
+
+	opened := game.UncoverCell(&CellRequest{Row: 4, Column: 4})
+
+	flagged := game.Board.Cells[10]
+	assert.Len(t, opened, 14)
+	assert.False(t, flagged.IsOpen)
+	assert.Equal(t, FlagRed, flagged.Flag)
+	assert.Equal(t, 0, game.Board.MinesRemaining)
+	assert.Equal(t, Playing, game.State)
+}
+
+func TestGame_MigrateFlags(t *testing.T) {
+	game := newMinedGame(2, 2, 0)
+	game.Board.Cells[0] = &Cell{IsMined: true, LegacyRedFlag: true, LegacyQuestionFlag: true}
+	game.Board.Cells[1] = &Cell{LegacyQuestionFlag: true}
+	game.Board.Cells[2] = &Cell{}
+
+	game.MigrateFlags()
+
+	assert.Equal(t, FlagRed, game.Board.Cells[0].Flag)
+	assert.False(t, game.Board.Cells[0].LegacyRedFlag)
+	assert.Equal(t, FlagQuestion, game.Board.Cells[1].Flag)
+	assert.Equal(t, FlagNone, game.Board.Cells[2].Flag)
+	assert.Equal(t, 0, game.Board.MinesRemaining)
+}
+
+func TestGame_MarkEndless(t *testing.T) {
+	game := newEndlessGame(40, 3)
+	game.UncoverEndless(&CellRequest{}, nil)
+	position := &CellRequest{Row: 20, Column: 20}
+
+	game.CycleMarkEndless(position, nil)
+	game.UncoverEndless(position, nil)
+	cell, _ := game.Endless.cell(20, 20)
+	assert.Equal(t, FlagRed, cell.Flag)
+	assert.False(t, cell.IsOpen)
+
+	game.UnmarkEndless(position, nil)
+	assert.Equal(t, FlagNone, cell.Flag)
+	assert.Equal(t, ErrOpenCell, game.MarkRedEndless(&CellRequest{}, nil))
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	maskRowSeparator = '/'
+	maskCell         = '#'
+	maskVoid         = '.'
+	// maxMaskRun keeps a single number in the mask from asking for a huge board
+	maxMaskRun = 10000
+	// maxMaskCells keeps the whole mask, rows times columns voids included, from asking for one
+	maxMaskCells = 1000 * 1000
+)
+
+// Shape is a parsed mask, Voids holds whether each position is a void, row after row
+type Shape struct {
+	Rows    int
+	Columns int
+	Voids   []bool
+}
+
+// ParseMask reads a shape mask. The rows are split by '/', '#' is a cell and '.' a void, and a number
+// before either repeats it, so "5#/#3.#/5#" is a donut. Every row must be as wide as the first one.
+func ParseMask(mask string) (*Shape, error) {
+	shape := &Shape{}
+	for rowNumber, row := range strings.Split(mask, string(maskRowSeparator)) {
+		columns := 0
+		count := 0
+		for _, symbol := range row {
+			switch {
+			case symbol >= '0' && symbol <= '9':
+				count = count*10 + int(symbol-'0')
+				if count > maxMaskRun {
+					return nil, fmt.Errorf("the mask repeats a symbol more than %d times on the row: %d", maxMaskRun, rowNumber+1)
+				}
+				continue
+			case symbol == maskCell || symbol == maskVoid:
+			default:
+				return nil, fmt.Errorf("the mask may only hold digits, '%c', '%c' and '%c', found: '%c'", maskCell, maskVoid, maskRowSeparator, symbol)
+			}
+
+			if count == 0 {
+				count = 1
+			}
+			if len(shape.Voids)+count > maxMaskCells {
+				return nil, fmt.Errorf("the mask may have up to %d positions, voids included", maxMaskCells)
+			}
+			for i := 0; i < count; i++ {
+				shape.Voids = append(shape.Voids, symbol == maskVoid)
+			}
+			columns = columns + count
+			count = 0
+		}
+
+		if count != 0 {
+			return nil, fmt.Errorf("the mask ends the row %d with a number and no symbol to repeat", rowNumber+1)
+		}
+		if rowNumber == 0 {
+			shape.Columns = columns
+		}
+		if columns == 0 || columns != shape.Columns {
+			return nil, fmt.Errorf("every row of the mask must have %d columns, the row %d has: %d", shape.Columns, rowNumber+1, columns)
+		}
+		shape.Rows++
+	}
+
+	if shape.Cells() == 0 {
+		return nil, fmt.Errorf("the mask has no cells")
+	}
+	return shape, nil
+}
+
+// Cells is the number of positions of the shape that are not voids
+func (shape *Shape) Cells() int {
+	cells := 0
+	for _, void := range shape.Voids {
+		if !void {
+			cells++
+		}
+	}
+	return cells
+}
+
+// fillVoids turns the positions the mask leaves out into voids, on every layer of the board.
+// The mask was validated with the new game request, a board without a valid mask has no voids.
+func (board *Board) fillVoids() {
+	if board.Mask == "" {
+		return
+	}
+	shape, err := ParseMask(board.Mask)
+	if err != nil || shape.Rows != board.Rows || shape.Columns != board.Columns {
+		return
+	}
+
+	for cellIndex, cell := range board.Cells {
+		_, row, column := board.cellCoordinates(cellIndex)
+		if shape.Voids[row*shape.Columns+column] {
+			cell.Void = true
+			board.Voids++
+		}
+	}
+}
+
+// IsVoid tells whether the cell is a position the mask of the board leaves out
+func (board *Board) IsVoid(cell *CellRequest) bool {
+	return board.isVoid(board.calculateCell(cell))
+}
+
+func (board *Board) isVoid(cellIndex int) bool {
+	return board.Voids > 0 && board.Cells[cellIndex].Void
+}
+
+// playableCells is the number of cells that are not voids, the ones that count toward the win
+func (board *Board) playableCells() int {
+	return len(board.Cells) - board.Voids
+}
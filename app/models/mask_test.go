@@ -0,0 +1,97 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParseMask(t *testing.T) {
+	tests := []struct {
+		name        string
+		mask        string
+		wantRows    int
+		wantColumns int
+		wantVoids   []int
+		wantErr     bool
+	}{
+		{name: "Success - donut", mask: "5#/#3.#/5#", wantRows: 3, wantColumns: 5, wantVoids: []int{6, 7, 8}},
+		{name: "Success - symbols without numbers", mask: ".#./###", wantRows: 2, wantColumns: 3, wantVoids: []int{0, 2}},
+		{name: "Success - numbers of several digits", mask: "12#", wantRows: 1, wantColumns: 12},
+		{name: "Error - rows of different width", mask: "3#/2#", wantErr: true},
+		{name: "Error - unknown symbol", mask: "3#/#x#", wantErr: true},
+		{name: "Error - number without symbol", mask: "3#/2#1", wantErr: true},
+		{name: "Error - empty row", mask: "3#//3#", wantErr: true},
+		{name: "Error - only voids", mask: "3./3.", wantErr: true},
+		{name: "Error - run too long", mask: "100000#", wantErr: true},
+		{name: "Error - too many positions", mask: strings.Repeat("10000#/", 100) + "10000#", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shape, err := ParseMask(tt.mask)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRows, shape.Rows)
+			assert.Equal(t, tt.wantColumns, shape.Columns)
+			var voids []int
+			for position, void := range shape.Voids {
+				if void {
+					voids = append(voids, position)
+				}
+			}
+			assert.Equal(t, tt.wantVoids, voids)
+		})
+	}
+}
+
+func newMaskedGame(mask string, mines int, seed int64) *Game {
+	shape, _ := ParseMask(mask)
+	game := &Game{State: Playing, Seed: seed}
+	game.Board = &Board{
+		Rows:       shape.Rows,
+		Columns:    shape.Columns,
+		Mines:      mines,
+		FirstClick: FirstClickOff,
+		Mask:       mask,
+		Cells:      make([]*Cell, shape.Rows*shape.Columns),
+	}
+	game.Board.InitBoard(game.NewRandom())
+	return game
+}
+
+func TestGame_UncoverCell_Mask(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		game := newMaskedGame("5#/#3.#/5#", 3, seed)
+
+		assert.Equal(t, 3, game.Board.Voids)
+		assert.Equal(t, []int{0, 2, 5}, game.Board.adjacentCells(1))
+		for cellIndex, cell := range game.Board.Cells {
+			if cell.Void {
+				assert.False(t, cell.IsMined)
+				assert.Empty(t, game.Board.adjacentCells(cellIndex))
+			}
+		}
+
+		for cellIndex, cell := range game.Board.Cells {
+			if !cell.IsMined && !cell.Void {
+				game.UncoverCell(game.Board.cellPosition(cellIndex))
+			}
+		}
+		assert.Equal(t, Won, game.State)
+		assert.Equal(t, 9, game.Board.OpenCells)
+	}
+}
+
+func TestGame_Hint_MaskedCentre(t *testing.T) {
+	game := newMaskedGame("3#/#.#/3#", 1, 1)
+	game.Board.PendingMines = true
+
+	hint := game.Hint()
+
+	assert.Equal(t, HintUncover, hint.Action)
+	assert.False(t, game.Board.IsVoid(&CellRequest{Row: hint.Row, Column: hint.Column}))
+}
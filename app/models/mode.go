@@ -0,0 +1,272 @@
+package models
+
+import "math/rand"
+
+type GameModeType string
+
+const (
+	GameModeClassic GameModeType = "classic"
+	// GameModeMultiMine lets a cell hold up to MaxMinesPerCell mines, the numbers count mines and not mined cells
+	GameModeMultiMine GameModeType = "multi-mine"
+	// GameModePractice starts with Lives, uncovering a mine costs one instead of the game
+	GameModePractice GameModeType = "practice"
+)
+
+// DefaultLives are the lives of a practice game when the request has none
+const DefaultLives = 3
+
+// GameMode holds the rules of a game, the engine goes through its hooks for every move.
+// A variant embeds ClassicMode, overrides the hooks it changes and is registered with RegisterGameMode.
+type GameMode interface {
+	// PlaceMines lays the mines of the board on the candidate cells, which it may reorder
+	PlaceMines(board *Board, random *rand.Rand, candidates []int)
+	// Uncover opens the cell and returns every cell it opened, in order
+	Uncover(game *Game, cellIndex int) []int
+	// Mark sets the flag of a covered cell, FlagNone takes it off. Count is the number of mines a red flag claims
+	// when the request has one.
+	Mark(game *Game, cellIndex int, flag FlagType, count int)
+	// Evaluate returns the state of a game in play once a move is made
+	Evaluate(game *Game) StateGame
+	// Ranked tells whether the games of the mode go to the leaderboards, which keep each ranked mode apart
+	Ranked() bool
+	// UndoLoss tells whether the move that lost a game may be undone
+	UndoLoss() bool
+	// MinesPerCell is the number of mines a cell of the board may hold, the cells count their mines when it is above one
+	MinesPerCell(board *Board) int
+	// StartingLives are the lives a new game starts with, requested are the ones the request asks for
+	StartingLives(requested int) int
+	// SupportsSolver tells whether hints and mine probabilities are available, the solver reads one mine per cell
+	SupportsSolver() bool
+}
+
+var gameModes = map[GameModeType]GameMode{
+	GameModeClassic:   ClassicMode{},
+	GameModeMultiMine: multiMineMode{},
+	GameModePractice:  practiceMode{},
+}
+
+// RegisterGameMode makes the mode available to new games under the name, it is meant to be called from an init function
+func RegisterGameMode(name GameModeType, mode GameMode) {
+	gameModes[name] = mode
+}
+
+func IsValidGameMode(mode GameModeType) bool {
+	_, ok := gameModes[mode]
+	return ok
+}
+
+// ClassicMode is the usual game, one mine at most on each cell. Uncovering a mine loses the game
+// and it is won once every safe cell is open.
+type ClassicMode struct{}
+
+func (ClassicMode) PlaceMines(board *Board, random *rand.Rand, candidates []int) {
+	for i := 0; i < board.Mines; i++ {
+		j := i + random.Intn(len(candidates)-i)
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+		board.Cells[candidates[i]].IsMined = true
+	}
+}
+
+func (ClassicMode) Uncover(game *Game, cellIndex int) []int {
+	if explodeMine(game, cellIndex) {
+		return nil
+	}
+	return game.Board.Reveal(cellIndex)
+}
+
+func (ClassicMode) Mark(game *Game, cellIndex int, flag FlagType, count int) {
+	game.Board.Cells[cellIndex].Flag = flag
+}
+
+func (ClassicMode) Evaluate(game *Game) StateGame {
+	if game.ExplodedMines > 0 {
+		return Lose
+	}
+	return clearedOrPlaying(game)
+}
+
+func (ClassicMode) Ranked() bool {
+	return true
+}
+
+func (ClassicMode) UndoLoss() bool {
+	return false
+}
+
+func (ClassicMode) MinesPerCell(board *Board) int {
+	return 1
+}
+
+func (ClassicMode) StartingLives(requested int) int {
+	return 0
+}
+
+func (ClassicMode) SupportsSolver() bool {
+	return true
+}
+
+// explodeMine marks the cell exploded when it holds a mine and tells whether it did
+func explodeMine(game *Game, cellIndex int) bool {
+	cell := game.Board.Cells[cellIndex]
+	if !cell.IsMined {
+		return false
+	}
+	if !cell.Exploded {
+		cell.Exploded = true
+		game.ExplodedMines++
+	}
+	return true
+}
+
+// clearedOrPlaying is Won once every safe cell is open
+func clearedOrPlaying(game *Game) StateGame {
+	if game.Board.OpenCells+game.Board.Mines == game.Board.playableCells() {
+		return Won
+	}
+	return Playing
+}
+
+// multiMineMode draws every mine among the free places of the cells, each cell having MaxMinesPerCell places.
+// The game is won once every safe cell is open and every mined cell has a red flag with its number of mines.
+type multiMineMode struct {
+	ClassicMode
+}
+
+func (multiMineMode) PlaceMines(board *Board, random *rand.Rand, candidates []int) {
+	places := make([]int, 0, len(candidates)*board.MinesPerCell())
+	for _, cellIndex := range candidates {
+		for i := 0; i < board.MinesPerCell(); i++ {
+			places = append(places, cellIndex)
+		}
+	}
+
+	for i := 0; i < board.Mines; i++ {
+		j := i + random.Intn(len(places)-i)
+		places[i], places[j] = places[j], places[i]
+		cell := board.Cells[places[i]]
+		cell.IsMined = true
+		cell.Mines++
+	}
+}
+
+func (mode multiMineMode) Mark(game *Game, cellIndex int, flag FlagType, count int) {
+	mode.ClassicMode.Mark(game, cellIndex, flag, count)
+	game.Board.Cells[cellIndex].FlagCount = 0
+	if flag == FlagRed {
+		game.Board.Cells[cellIndex].FlagCount = max(count, 1)
+	}
+}
+
+func (multiMineMode) MinesPerCell(board *Board) int {
+	return max(board.MaxMinesPerCell, 1)
+}
+
+func (multiMineMode) SupportsSolver() bool {
+	return false
+}
+
+func (multiMineMode) Evaluate(game *Game) StateGame {
+	if game.ExplodedMines > 0 {
+		return Lose
+	}
+	for cellIndex, cell := range game.Board.Cells {
+		if game.Board.isVoid(cellIndex) {
+			continue
+		}
+		if cell.IsMined && (cell.Flag != FlagRed || cell.FlagCount != cell.Mines) {
+			return Playing
+		}
+		if !cell.IsMined && !cell.IsOpen {
+			return Playing
+		}
+	}
+	return Won
+}
+
+// practiceMode forgives the mines: uncovering one costs a life and marks it exploded, the game goes on
+// until the last life is lost. Practice games are not ranked and the move that lost one may be undone.
+type practiceMode struct {
+	ClassicMode
+}
+
+func (practiceMode) Uncover(game *Game, cellIndex int) []int {
+	if game.Board.Cells[cellIndex].Exploded {
+		return nil
+	}
+	if explodeMine(game, cellIndex) {
+		game.Lives--
+		return nil
+	}
+	return game.Board.Reveal(cellIndex)
+}
+
+func (practiceMode) Evaluate(game *Game) StateGame {
+	if game.Lives <= 0 {
+		return Lose
+	}
+	return clearedOrPlaying(game)
+}
+
+func (practiceMode) Ranked() bool {
+	return false
+}
+
+func (practiceMode) UndoLoss() bool {
+	return true
+}
+
+func (practiceMode) StartingLives(requested int) int {
+	if requested == 0 {
+		return DefaultLives
+	}
+	return requested
+}
+
+func (board *Board) mode() GameMode {
+	if mode, ok := gameModes[board.Mode]; ok {
+		return mode
+	}
+	return ClassicMode{}
+}
+
+// Ranked tells whether the mode of the board lets the game go to the leaderboards
+func (board *Board) Ranked() bool {
+	return board.mode().Ranked()
+}
+
+// MinesPerCell is the number of mines a cell of the board may hold in its mode
+func (board *Board) MinesPerCell() int {
+	return board.mode().MinesPerCell(board)
+}
+
+// StartingLives are the lives a new game on the board starts with, requested are the ones the request asks for
+func (board *Board) StartingLives(requested int) int {
+	return board.mode().StartingLives(requested)
+}
+
+// SupportsSolver tells whether the mode of the board lets hints and mine probabilities be asked for
+func (board *Board) SupportsSolver() bool {
+	return board.mode().SupportsSolver()
+}
+
+// mineCount is the number of mines on the cell, classic cells only say whether they are mined
+func (cell *Cell) mineCount() int {
+	if cell.Mines > 0 {
+		return cell.Mines
+	}
+	if cell.IsMined {
+		return 1
+	}
+	return 0
+}
+
+// flaggedMines is the number of mines the red flag of the cell claims, a flag without a count claims one
+func (cell *Cell) flaggedMines() int {
+	if cell.Flag != FlagRed {
+		return 0
+	}
+	if cell.FlagCount > 0 {
+		return cell.FlagCount
+	}
+	return 1
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newMultiMineGame(rows int, columns int, mines int, maxMinesPerCell int, seed int64) *Game {
+	game := &Game{State: Playing, Seed: seed}
+	game.Board = &Board{
+		Rows:            rows,
+		Columns:         columns,
+		Mines:           mines,
+		FirstClick:      FirstClickOff,
+		Mode:            GameModeMultiMine,
+		MaxMinesPerCell: maxMinesPerCell,
+		Cells:           make([]*Cell, rows*columns),
+	}
+	game.Board.InitBoard(game.NewRandom())
+	return game
+}
+
+func TestMultiMineMode_PlaceMines(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		game := newMultiMineGame(6, 6, 30, 3, seed)
+
+		mines := 0
+		for cellIndex, cell := range game.Board.Cells {
+			assert.True(t, cell.Mines <= 3)
+			assert.Equal(t, cell.Mines > 0, cell.IsMined)
+			mines = mines + cell.Mines
+
+			minesAround := 0
+			for _, adjacent := range game.Board.adjacentCells(cellIndex) {
+				minesAround = minesAround + game.Board.Cells[adjacent].Mines
+			}
+			assert.Equal(t, minesAround, cell.MinesAround)
+		}
+		assert.Equal(t, 30, mines)
+	}
+}
+
+func TestMultiMineMode_Won(t *testing.T) {
+	game := newMultiMineGame(4, 4, 6, 3, 1)
+	var mined []int
+	for cellIndex, cell := range game.Board.Cells {
+		if cell.IsMined {
+			mined = append(mined, cellIndex)
+		} else {
+			game.UncoverCell(game.Board.cellPosition(cellIndex))
+		}
+	}
+	assert.Equal(t, Playing, game.State)
+
+	for _, cellIndex := range mined {
+		position := game.Board.cellPosition(cellIndex)
+		position.Count = game.Board.Cells[cellIndex].Mines + 1
+		game.MarkRed(position)
+	}
+	assert.Equal(t, Playing, game.State)
+
+	for _, cellIndex := range mined {
+		position := game.Board.cellPosition(cellIndex)
+		position.Count = game.Board.Cells[cellIndex].Mines
+		game.MarkRed(position)
+	}
+	assert.Equal(t, Won, game.State)
+}
+
+func TestGame_ChordCell_MultiMine(t *testing.T) {
+	// 2 mines on the corner, the number next to it is only chorded once its flag claims both
+	game := newMinedGame(3, 3, 0)
+	game.Board.Mode = GameModeMultiMine
+	game.Board.MaxMinesPerCell = 2
+	game.Board.Mines = 2
+	game.Board.Cells[0].Mines = 2
+	game.Board.countMinesAround()
+	game.UncoverCell(&CellRequest{Row: 2, Column: 2})
+	assert.Equal(t, 2, game.Board.Cells[4].MinesAround)
+
+	game.MarkRed(&CellRequest{Row: 1, Column: 1, Count: 1})
+	assert.Empty(t, game.ChordCell(&CellRequest{Row: 2, Column: 2}))
+
+	game.MarkRed(&CellRequest{Row: 1, Column: 1, Count: 2})
+	game.ChordCell(&CellRequest{Row: 2, Column: 2})
+
+	assert.Equal(t, 8, game.Board.OpenCells)
+	assert.Equal(t, Won, game.State)
+}
+
+// sweepOnlyMode is a variant that only changes how the game ends, uncovering a mine never loses
+type sweepOnlyMode struct {
+	ClassicMode
+}
+
+func (sweepOnlyMode) Evaluate(game *Game) StateGame {
+	game.ExplodedMines = 0
+	return ClassicMode{}.Evaluate(game)
+}
+
+func TestRegisterGameMode(t *testing.T) {
+	RegisterGameMode("sweep-only", sweepOnlyMode{})
+	defer delete(gameModes, "sweep-only")
+	game := newMinedGame(2, 2, 0)
+	game.Board.Mode = "sweep-only"
+
+	assert.True(t, IsValidGameMode("sweep-only"))
+	game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+	assert.Equal(t, Playing, game.State)
+	for _, position := range []*CellRequest{{Row: 1, Column: 2}, {Row: 2, Column: 1}, {Row: 2, Column: 2}} {
+		game.UncoverCell(position)
+	}
+	assert.Equal(t, Won, game.State)
+}
+
+func TestBoard_ModeRules(t *testing.T) {
+	tests := []struct {
+		name             string
+		board            Board
+		requestedLives   int
+		wantMinesPerCell int
+		wantLives        int
+		wantSolver       bool
+	}{
+		{name: "Classic", board: Board{Mode: GameModeClassic}, wantMinesPerCell: 1, wantSolver: true},
+		{name: "Stored without mode", board: Board{}, wantMinesPerCell: 1, wantSolver: true},
+		{name: "Multi-mine", board: Board{Mode: GameModeMultiMine, MaxMinesPerCell: 3}, wantMinesPerCell: 3},
+		{name: "Practice", board: Board{Mode: GameModePractice}, wantMinesPerCell: 1, wantLives: DefaultLives, wantSolver: true},
+		{name: "Practice with lives", board: Board{Mode: GameModePractice}, requestedLives: 5, wantMinesPerCell: 1, wantLives: 5, wantSolver: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantMinesPerCell, tt.board.MinesPerCell())
+			assert.Equal(t, tt.wantLives, tt.board.StartingLives(tt.requestedLives))
+			assert.Equal(t, tt.wantSolver, tt.board.SupportsSolver())
+		})
+	}
+}
+
+func TestPracticeMode_Uncover(t *testing.T) {
+	// * . .
+	// . . .
+	// . . *
+	game := newMinedGame(3, 3, 0, 8)
+	game.Board.Mode = GameModePractice
+	game.Lives = 2
+
+	game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+	game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+
+	assert.Equal(t, Playing, game.State)
+	assert.Equal(t, 1, game.Lives)
+	assert.Equal(t, 1, game.ExplodedMines)
+	assert.True(t, game.Board.Cells[0].Exploded)
+	assert.False(t, game.Board.Cells[0].IsOpen)
+	assert.False(t, game.Board.Ranked())
+
+	// the exploded mine is known, so the 1 next to it proves its other cells safe
+	game.UncoverCell(&CellRequest{Row: 1, Column: 2})
+	hint := game.Hint()
+	assert.Equal(t, HintUncover, hint.Action)
+
+	game.UncoverCell(&CellRequest{Row: 3, Column: 3})
+	assert.Equal(t, Lose, game.State)
+	assert.Equal(t, 0, game.Lives)
+	assert.Equal(t, 2, game.ExplodedMines)
+}
+
+func TestPracticeMode_Won(t *testing.T) {
+	game := newMinedGame(2, 2, 0)
+	game.Board.Mode = GameModePractice
+	game.Lives = 1
+
+	for _, position := range []*CellRequest{{Row: 1, Column: 2}, {Row: 2, Column: 1}, {Row: 2, Column: 2}} {
+		game.UncoverCell(position)
+	}
+
+	assert.Equal(t, Won, game.State)
+	assert.Equal(t, 0, game.ExplodedMines)
+}
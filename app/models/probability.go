@@ -0,0 +1,455 @@
+package models
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ProbabilityMap holds, for every covered cell, the chance it holds a mine given only what the player sees.
+// Open and void cells are null. Exact is false when part of the board was estimated instead of enumerated.
+// Flat boards fill Probabilities by row and column, 3D boards fill Layers by layer, row and column.
+type ProbabilityMap struct {
+	Exact         bool           `json:"exact"`
+	Probabilities [][]*float64   `json:"probabilities"`
+	Layers        [][][]*float64 `json:"layers,omitempty"`
+}
+
+const (
+	// enumerationBudget is the number of search steps a frontier may take to be enumerated exactly
+	enumerationBudget = 1 << 20
+	// exactFrontierLimit is the largest frontier whose components are combined exactly
+	exactFrontierLimit = 256
+	sampleBudget       = 1 << 12
+	maxSamples         = 1 << 12
+)
+
+// component is a group of covered cells tied together by the open numbers around them
+type component struct {
+	cells       []int
+	constraints []*constraint
+}
+
+// componentResult is, for every number of mines the component may hold, how many layouts hold that many
+// and the share of those layouts that put a mine on each cell
+type componentResult struct {
+	cells      []int
+	logWeights []float64
+	mineShares [][]float64
+}
+
+// MineProbabilities computes the mine probability of every covered cell. The covered cells next to open numbers
+// are enumerated when the search fits in its budget, otherwise they are sampled until the deadline. The rest of
+// the covered cells share the mines left evenly.
+func (board *Board) MineProbabilities(random *rand.Rand, deadline time.Time) *ProbabilityMap {
+	probabilities := make([]float64, len(board.Cells))
+	probabilityMap := &ProbabilityMap{Exact: true}
+
+	// exploded mines are known, they are left out of the covered cells and of the mines to share
+	covered, exploded := 0, 0
+	for _, cell := range board.Cells {
+		if cell.Exploded {
+			exploded++
+		} else if !cell.IsOpen && !cell.Void {
+			covered++
+		}
+	}
+
+	frontier := 0
+	inFrontier := make(map[int]bool)
+	var results []*componentResult
+	for _, c := range splitComponents(newSolver(board).constraints()) {
+		result := c.enumerate(deadline)
+		if result == nil {
+			probabilityMap.Exact = false
+			result = c.sample(random, deadline)
+		}
+		if result == nil {
+			// not a single layout was found in time, its cells are left with the interior ones
+			continue
+		}
+		frontier = frontier + len(c.cells)
+		for _, cellIndex := range c.cells {
+			inFrontier[cellIndex] = true
+		}
+		results = append(results, result)
+	}
+
+	interior := covered - frontier
+	var interiorProbability float64
+	if probabilityMap.Exact && frontier <= exactFrontierLimit {
+		interiorProbability = combineExact(results, interior, board.Mines-exploded, probabilities)
+	} else {
+		probabilityMap.Exact = false
+		interiorProbability = combineApproximate(results, interior, board.Mines-exploded, probabilities)
+	}
+
+	layers := make([][][]*float64, board.layerCount())
+	for layer := range layers {
+		layers[layer] = make([][]*float64, board.Rows)
+		for row := range layers[layer] {
+			layers[layer][row] = make([]*float64, board.Columns)
+		}
+	}
+	for cellIndex, cell := range board.Cells {
+		if cell.IsOpen || cell.Void {
+			continue
+		}
+		probability := interiorProbability
+		if cell.Exploded {
+			probability = 1
+		} else if inFrontier[cellIndex] {
+			probability = probabilities[cellIndex]
+		}
+		layer, row, column := board.cellCoordinates(cellIndex)
+		layers[layer][row][column] = &probability
+	}
+
+	if len(layers) == 1 {
+		probabilityMap.Probabilities = layers[0]
+	} else {
+		probabilityMap.Layers = layers
+	}
+	return probabilityMap
+}
+
+func splitComponents(constraints []*constraint) []*component {
+	constraintsByCell := make(map[int][]*constraint)
+	for _, c := range constraints {
+		for _, cellIndex := range c.cells {
+			constraintsByCell[cellIndex] = append(constraintsByCell[cellIndex], c)
+		}
+	}
+
+	var components []*component
+	visited := make(map[*constraint]bool)
+	added := make(map[int]bool)
+	for _, first := range constraints {
+		if visited[first] {
+			continue
+		}
+		visited[first] = true
+		// breadth first, so cells sharing numbers are close in the search order and prune each other early
+		c := &component{constraints: []*constraint{first}}
+		for next := 0; next < len(c.constraints); next++ {
+			for _, cellIndex := range c.constraints[next].cells {
+				if added[cellIndex] {
+					continue
+				}
+				added[cellIndex] = true
+				c.cells = append(c.cells, cellIndex)
+				for _, other := range constraintsByCell[cellIndex] {
+					if !visited[other] {
+						visited[other] = true
+						c.constraints = append(c.constraints, other)
+					}
+				}
+			}
+		}
+		components = append(components, c)
+	}
+	return components
+}
+
+// search walks the layouts of a component, assigning its cells in order and dropping a branch
+// as soon as a number gets more mines than it shows or no longer room for them
+type search struct {
+	component       *component
+	cellConstraints [][]int
+	needed          []int
+	free            []int
+	mined           []bool
+	mines           int
+	steps           int
+	budget          int
+	deadline        time.Time
+	random          *rand.Rand
+	found           bool
+	counts          []float64
+	mineCounts      [][]float64
+}
+
+func (c *component) newSearch(deadline time.Time) *search {
+	s := &search{
+		component:       c,
+		cellConstraints: make([][]int, len(c.cells)),
+		needed:          make([]int, len(c.constraints)),
+		free:            make([]int, len(c.constraints)),
+		mined:           make([]bool, len(c.cells)),
+		deadline:        deadline,
+		counts:          make([]float64, len(c.cells)+1),
+		mineCounts:      make([][]float64, len(c.cells)+1),
+	}
+	position := make(map[int]int, len(c.cells))
+	for i, cellIndex := range c.cells {
+		position[cellIndex] = i
+	}
+	for constraintIndex, con := range c.constraints {
+		s.needed[constraintIndex] = con.mines
+		s.free[constraintIndex] = len(con.cells)
+		for _, cellIndex := range con.cells {
+			s.cellConstraints[position[cellIndex]] = append(s.cellConstraints[position[cellIndex]], constraintIndex)
+		}
+	}
+	return s
+}
+
+func (c *component) enumerate(deadline time.Time) *componentResult {
+	s := c.newSearch(deadline)
+	s.budget = enumerationBudget
+	if !s.place(0) {
+		return nil
+	}
+	return s.result()
+}
+
+func (c *component) sample(random *rand.Rand, deadline time.Time) *componentResult {
+	s := c.newSearch(deadline)
+	s.random = random
+	samples := 0
+	for i := 0; i < maxSamples && (samples == 0 || time.Now().Before(deadline)); i++ {
+		s.steps = 0
+		s.budget = sampleBudget
+		s.found = false
+		s.place(0)
+		if s.found {
+			samples++
+		}
+	}
+	if samples == 0 {
+		return nil
+	}
+	return s.result()
+}
+
+func (s *search) place(position int) bool {
+	if position == len(s.mined) {
+		s.record()
+		return true
+	}
+
+	s.steps++
+	if s.steps > s.budget || (s.steps%1024 == 0 && time.Now().After(s.deadline)) {
+		return false
+	}
+
+	values := []bool{false, true}
+	if s.random != nil && s.random.Intn(2) == 1 {
+		values[0], values[1] = true, false
+	}
+	for _, isMined := range values {
+		valid := s.assign(position, isMined)
+		completed := true
+		if valid {
+			completed = s.place(position + 1)
+		}
+		s.unassign(position, isMined)
+		if !completed {
+			return false
+		}
+		if s.found {
+			return true
+		}
+	}
+	return true
+}
+
+func (s *search) assign(position int, isMined bool) bool {
+	s.mined[position] = isMined
+	valid := true
+	for _, constraintIndex := range s.cellConstraints[position] {
+		s.free[constraintIndex]--
+		if isMined {
+			s.needed[constraintIndex]--
+		}
+		if s.needed[constraintIndex] < 0 || s.needed[constraintIndex] > s.free[constraintIndex] {
+			valid = false
+		}
+	}
+	if isMined {
+		s.mines++
+	}
+	return valid
+}
+
+func (s *search) unassign(position int, isMined bool) {
+	for _, constraintIndex := range s.cellConstraints[position] {
+		s.free[constraintIndex]++
+		if isMined {
+			s.needed[constraintIndex]++
+		}
+	}
+	if isMined {
+		s.mines--
+	}
+	s.mined[position] = false
+}
+
+func (s *search) record() {
+	s.found = s.random != nil
+	s.counts[s.mines]++
+	if s.mineCounts[s.mines] == nil {
+		s.mineCounts[s.mines] = make([]float64, len(s.mined))
+	}
+	for position, isMined := range s.mined {
+		if isMined {
+			s.mineCounts[s.mines][position]++
+		}
+	}
+}
+
+func (s *search) result() *componentResult {
+	result := &componentResult{
+		cells:      s.component.cells,
+		logWeights: make([]float64, len(s.counts)),
+		mineShares: make([][]float64, len(s.counts)),
+	}
+	for mines, count := range s.counts {
+		result.logWeights[mines] = math.Log(count)
+		if count == 0 {
+			continue
+		}
+		result.mineShares[mines] = make([]float64, len(s.mined))
+		for position, mineCount := range s.mineCounts[mines] {
+			result.mineShares[mines][position] = mineCount / count
+		}
+	}
+	return result
+}
+
+// combineExact weighs every way of splitting the mines between the components and the interior cells
+// by the number of layouts it allows, and returns the probability of an interior cell
+func combineExact(results []*componentResult, interior int, mines int, probabilities []float64) float64 {
+	prefixes := make([][]float64, len(results)+1)
+	prefixes[0] = []float64{0}
+	for i, result := range results {
+		prefixes[i+1] = convolve(prefixes[i], result.logWeights)
+	}
+	suffixes := make([][]float64, len(results)+1)
+	suffixes[len(results)] = []float64{0}
+	for i := len(results) - 1; i >= 0; i-- {
+		suffixes[i] = convolve(results[i].logWeights, suffixes[i+1])
+	}
+
+	all := prefixes[len(results)]
+	logTotal := math.Inf(-1)
+	for frontierMines, logWeight := range all {
+		logTotal = logAdd(logTotal, logWeight+logCombinations(interior, mines-frontierMines))
+	}
+	if math.IsInf(logTotal, -1) {
+		return 0
+	}
+
+	for i, result := range results {
+		others := convolve(prefixes[i], suffixes[i+1])
+		for componentMines, logWeight := range result.logWeights {
+			if result.mineShares[componentMines] == nil {
+				continue
+			}
+			logOthers := math.Inf(-1)
+			for otherMines, logOtherWeight := range others {
+				logOthers = logAdd(logOthers, logOtherWeight+logCombinations(interior, mines-componentMines-otherMines))
+			}
+			share := math.Exp(logWeight + logOthers - logTotal)
+			for position, cellIndex := range result.cells {
+				probabilities[cellIndex] = probabilities[cellIndex] + share*result.mineShares[componentMines][position]
+			}
+		}
+	}
+
+	if interior == 0 {
+		return 0
+	}
+	interiorMines := 0.0
+	for frontierMines, logWeight := range all {
+		share := math.Exp(logWeight + logCombinations(interior, mines-frontierMines) - logTotal)
+		interiorMines = interiorMines + share*float64(mines-frontierMines)
+	}
+	return interiorMines / float64(interior)
+}
+
+// combineApproximate treats the components as independent, each cell of the board holding a mine with the same
+// odds unless a number says otherwise. The odds are searched so the expected mines match the mines of the board.
+func combineApproximate(results []*componentResult, interior int, mines int, probabilities []float64) float64 {
+	expectedMines := func(logOdds float64) float64 {
+		expected := float64(interior) / (1 + math.Exp(-logOdds))
+		for _, result := range results {
+			logTotal := math.Inf(-1)
+			for componentMines, logWeight := range result.logWeights {
+				logTotal = logAdd(logTotal, logWeight+float64(componentMines)*logOdds)
+			}
+			for componentMines, logWeight := range result.logWeights {
+				expected = expected + float64(componentMines)*math.Exp(logWeight+float64(componentMines)*logOdds-logTotal)
+			}
+		}
+		return expected
+	}
+
+	low, high := -50.0, 50.0
+	for i := 0; i < 100; i++ {
+		middle := (low + high) / 2
+		if expectedMines(middle) < float64(mines) {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	logOdds := (low + high) / 2
+
+	for _, result := range results {
+		logTotal := math.Inf(-1)
+		for componentMines, logWeight := range result.logWeights {
+			logTotal = logAdd(logTotal, logWeight+float64(componentMines)*logOdds)
+		}
+		for componentMines, logWeight := range result.logWeights {
+			if result.mineShares[componentMines] == nil {
+				continue
+			}
+			share := math.Exp(logWeight + float64(componentMines)*logOdds - logTotal)
+			for position, cellIndex := range result.cells {
+				probabilities[cellIndex] = probabilities[cellIndex] + share*result.mineShares[componentMines][position]
+			}
+		}
+	}
+	return 1 / (1 + math.Exp(-logOdds))
+}
+
+// convolve adds up, in log space, the weights of every way two groups can hold each total of mines
+func convolve(a []float64, b []float64) []float64 {
+	result := make([]float64, len(a)+len(b)-1)
+	for i := range result {
+		result[i] = math.Inf(-1)
+	}
+	for i, logA := range a {
+		if math.IsInf(logA, -1) {
+			continue
+		}
+		for j, logB := range b {
+			result[i+j] = logAdd(result[i+j], logA+logB)
+		}
+	}
+	return result
+}
+
+func logAdd(a float64, b float64) float64 {
+	if math.IsInf(a, -1) {
+		return b
+	}
+	if math.IsInf(b, -1) {
+		return a
+	}
+	if a < b {
+		a, b = b, a
+	}
+	return a + math.Log1p(math.Exp(b-a))
+}
+
+func logCombinations(n int, k int) float64 {
+	if k < 0 || k > n {
+		return math.Inf(-1)
+	}
+	logN, _ := math.Lgamma(float64(n + 1))
+	logK, _ := math.Lgamma(float64(k + 1))
+	logRest, _ := math.Lgamma(float64(n - k + 1))
+	return logN - logK - logRest
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestBoard_MineProbabilities(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int
+		columns int
+		mines   []int
+		open    []int
+		want    []float64
+	}{
+		{
+			// 1 * .
+			name:    "Success - forced mine and safe interior",
+			rows:    1,
+			columns: 3,
+			mines:   []int{1},
+			open:    []int{0},
+			want:    []float64{-1, 1, 0},
+		},
+		{
+			// 1 * . .  with a second mine somewhere in the interior
+			name:    "Success - mines left shared by the interior",
+			rows:    1,
+			columns: 4,
+			mines:   []int{1, 3},
+			open:    []int{0},
+			want:    []float64{-1, 1, 0.5, 0.5},
+		},
+		{
+			// . 1 * 1 . . * .  the numbers take one mine in the middle or two on the sides,
+			//                  and one mine leaves three ways to place the other on the interior
+			name:    "Success - frontier weighed against the interior",
+			rows:    1,
+			columns: 8,
+			mines:   []int{2, 6},
+			open:    []int{1, 3},
+			want:    []float64{0.25, -1, 0.75, -1, 0.25, 0.25, 0.25, 0.25},
+		},
+		{
+			// . *
+			// 1 1
+			name:    "Success - fifty-fifty",
+			rows:    2,
+			columns: 2,
+			mines:   []int{1},
+			open:    []int{2, 3},
+			want:    []float64{0.5, 0.5, -1, -1},
+		},
+		{
+			// * . . . *
+			// 1 1 . . .  one mine among the first two cells and another among the four far cells
+			name:    "Success - frontier and interior",
+			rows:    2,
+			columns: 5,
+			mines:   []int{0, 4},
+			open:    []int{5, 6},
+			want:    []float64{0.5, 0.5, 0, 0.25, 0.25, -1, -1, 0, 0.25, 0.25},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newMinedGame(tt.rows, tt.columns, tt.mines...)
+			for _, open := range tt.open {
+				game.Board.Cells[open].IsOpen = true
+			}
+
+			probabilityMap := game.Board.MineProbabilities(rand.New(rand.NewSource(1)), time.Now().Add(time.Second))
+
+			assert.True(t, probabilityMap.Exact)
+			for cellIndex, want := range tt.want {
+				probability := probabilityMap.Probabilities[cellIndex/tt.columns][cellIndex%tt.columns]
+				if want < 0 {
+					assert.Nil(t, probability)
+					continue
+				}
+				assert.InDelta(t, want, *probability, 1e-9)
+			}
+		})
+	}
+}
+
+func TestBoard_MineProbabilities_LargeBoard(t *testing.T) {
+	game := newSeededGame(100, 100, 2000, FirstClickSafeOpening, 1)
+	game.UncoverCell(&CellRequest{Row: 50, Column: 50})
+	for _, deduction := range newSolver(game.Board).deduce() {
+		if !deduction.IsMined {
+			game.Board.Reveal(deduction.CellIndex)
+		}
+	}
+
+	probabilityMap := game.Board.MineProbabilities(rand.New(rand.NewSource(1)), time.Now().Add(time.Second))
+
+	expectedMines := 0.0
+	for _, row := range probabilityMap.Probabilities {
+		for _, probability := range row {
+			if probability != nil {
+				assert.True(t, *probability >= 0 && *probability <= 1)
+				expectedMines = expectedMines + *probability
+			}
+		}
+	}
+	assert.InDelta(t, 2000, expectedMines, 1)
+}
+
+func TestBoard_MineProbabilities_Space(t *testing.T) {
+	// two layers of two cells, every cell touches the other three
+	game := newMinedGame(1, 2)
+	game.Board.Layers = 2
+	game.Board.Cells = append(game.Board.Cells, &Cell{IsMined: true}, &Cell{})
+	game.Board.Mines = 1
+	game.Board.countMinesAround()
+	game.Board.Cells[0].IsOpen = true
+
+	probabilityMap := game.Board.MineProbabilities(rand.New(rand.NewSource(1)), time.Now().Add(time.Second))
+
+	assert.True(t, probabilityMap.Exact)
+	assert.Nil(t, probabilityMap.Probabilities)
+	assert.Nil(t, probabilityMap.Layers[0][0][0])
+	for _, probability := range []*float64{probabilityMap.Layers[0][0][1], probabilityMap.Layers[1][0][0], probabilityMap.Layers[1][0][1]} {
+		assert.InDelta(t, 1.0/3, *probability, 1e-9)
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrReplayInPlay = errors.New("the game is not finished, only games won or lost for good can be replayed")
+	ErrReplayCursor = errors.New("the cursor is not an event of the game")
+)
+
+// Replay is every event of a finished game with the board it left, in order
+type Replay struct {
+	GameId string        `json:"gameId"`
+	Seed   int64         `json:"seed,string"`
+	Steps  []*ReplayStep `json:"steps"`
+}
+
+// ReplayStep is the game as an event left it, Board on normal games and Chunks on endless ones
+type ReplayStep struct {
+	Seq       int          `json:"seq"`
+	Type      EventType    `json:"type"`
+	Action    GameAction   `json:"action,omitempty"`
+	Cell      *CellRequest `json:"cell,omitempty"`
+	At        time.Time    `json:"at"`
+	State     StateGame    `json:"state"`
+	ElapsedMs int64        `json:"elapsedMs"`
+	Board     *BoardView   `json:"board,omitempty"`
+	Chunks    []*ChunkView `json:"chunks,omitempty"`
+}
+
+// ReplayStep returns the game as the event just applied left it, the view copies the board so the game may go on.
+// Only finished games are replayed, so every step shows the whole board and the last ones the outcome of each cell.
+func (game *Game) ReplayStep(event *GameEvent) *ReplayStep {
+	step := &ReplayStep{
+		Seq:       event.Seq,
+		Type:      event.Type,
+		Action:    event.Action,
+		Cell:      event.Cell,
+		At:        event.At,
+		State:     game.State,
+		ElapsedMs: game.ElapsedMs,
+	}
+	if game.Board != nil {
+		step.Board = game.Board.view(true, game.State)
+	}
+	if game.Endless != nil {
+		step.Chunks = chunkViews(game.Endless.Chunks, true, game.State)
+	}
+	return step
+}
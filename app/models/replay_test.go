@@ -0,0 +1,49 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGame_ReplayStep(t *testing.T) {
+	game := newMinedGame(3, 3, 0)
+	flagged := &GameEvent{Seq: 1, Type: EventFlagged, Action: ActionMarkRed, Cell: &CellRequest{Row: 1, Column: 1}, At: at(1)}
+	game.ApplyEvent(flagged, nil)
+	step := game.ReplayStep(flagged)
+
+	uncovered := &GameEvent{Seq: 2, Type: EventUncovered, Cell: &CellRequest{Row: 3, Column: 3}, At: at(4)}
+	game.ApplyEvent(uncovered, nil)
+	next := game.ReplayStep(uncovered)
+
+	// the step keeps the board as the event left it, the moves after it do not change it
+	assert.Equal(t, 1, step.Seq)
+	assert.Equal(t, ActionMarkRed, step.Action)
+	assert.Equal(t, Playing, step.State)
+	assert.Equal(t, 0, step.Board.OpenCells)
+	assert.False(t, step.Board.Cells[8].IsOpen)
+	assert.Equal(t, FlagRed, step.Board.Cells[0].Flag)
+
+	assert.Equal(t, Won, next.State)
+	assert.True(t, next.Board.Cells[8].IsOpen)
+	assert.Equal(t, int64(3000), next.ElapsedMs)
+}
+
+func TestGame_ReplayStep_Endless(t *testing.T) {
+	game := newEndlessGame(40, 1)
+	game.UncoverEndless(&CellRequest{}, nil)
+	marked := &GameEvent{Seq: 1, Type: EventFlagged, Action: ActionMarkQuestion, Cell: &CellRequest{Row: 30, Column: 30}, At: at(1)}
+	game.ApplyEvent(marked, nil)
+
+	step := game.ReplayStep(marked)
+	game.ApplyEvent(&GameEvent{Seq: 2, Type: EventFlagged, Action: ActionUnmark, Cell: marked.Cell, At: at(2)}, nil)
+
+	assert.Nil(t, step.Board)
+	assert.Len(t, step.Chunks, len(game.Endless.Chunks))
+	cell, _ := game.Endless.cell(30, 30)
+	assert.Equal(t, FlagNone, cell.Flag)
+	for _, chunk := range step.Chunks {
+		if chunk.Row == 1 && chunk.Column == 1 {
+			assert.Equal(t, FlagQuestion, chunk.Cells[14*ChunkSize+14].Flag)
+		}
+	}
+}
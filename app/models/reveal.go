@@ -0,0 +1,67 @@
+package models
+
+// CellOutcome tells what a cell turned out to be once the game is over
+type CellOutcome string
+
+const (
+	// OutcomeExploded is a mine the player uncovered, practice games may have several
+	OutcomeExploded CellOutcome = "exploded"
+	// OutcomeMine is a mine the player had not flagged when the game was lost
+	OutcomeMine CellOutcome = "mine"
+	// OutcomeFlaggedMine is a mine the player flagged, with its number of mines on multi-mine boards
+	OutcomeFlaggedMine CellOutcome = "flagged-mine"
+	// OutcomeWrongFlag is a red flag on a cell without mines, or claiming another number of mines than the cell holds
+	OutcomeWrongFlag CellOutcome = "wrong-flag"
+	// OutcomeUnflaggedMine is a mine the player had not flagged when the game was won, it is flagged for them
+	OutcomeUnflaggedMine CellOutcome = "unflagged-mine"
+)
+
+// over tells whether the game is won or lost, so its board has nothing left to hide
+func (state StateGame) over() bool {
+	return state == Won || state == Lose
+}
+
+// outcome annotates the cell of a game over, the safe cells without a flag need none
+func (cell *Cell) outcome(state StateGame) CellOutcome {
+	if !state.over() {
+		return ""
+	}
+	if cell.Exploded {
+		return OutcomeExploded
+	}
+	if cell.mineCount() == 0 {
+		if cell.Flag == FlagRed {
+			return OutcomeWrongFlag
+		}
+		return ""
+	}
+	if cell.Flag == FlagRed && !cell.AutoFlagged {
+		if cell.flaggedMines() != cell.mineCount() {
+			return OutcomeWrongFlag
+		}
+		return OutcomeFlaggedMine
+	}
+	if state == Won {
+		return OutcomeUnflaggedMine
+	}
+	return OutcomeMine
+}
+
+// flagMines puts a red flag on every mine of a won board the player had not flagged,
+// returning the cells it flagged as they were before
+func (board *Board) flagMines() []*CellSnapshot {
+	var flagged []*CellSnapshot
+	for cellIndex, cell := range board.Cells {
+		if cell.mineCount() == 0 || (cell.Flag == FlagRed && cell.flaggedMines() == cell.mineCount()) {
+			continue
+		}
+		flagged = append(flagged, &CellSnapshot{Index: cellIndex, Cell: *cell})
+		cell.Flag = FlagRed
+		if board.MinesPerCell() > 1 {
+			cell.FlagCount = cell.Mines
+		}
+		cell.AutoFlagged = true
+	}
+	board.countMinesRemaining()
+	return flagged
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCell_Outcome(t *testing.T) {
+	tests := []struct {
+		name  string
+		cell  Cell
+		state StateGame
+		want  CellOutcome
+	}{
+		{name: "Playing", cell: Cell{IsMined: true}, state: Playing},
+		{name: "Exploded", cell: Cell{IsMined: true, Exploded: true}, state: Lose, want: OutcomeExploded},
+		{name: "Mine", cell: Cell{IsMined: true, Flag: FlagQuestion}, state: Lose, want: OutcomeMine},
+		{name: "Flagged mine", cell: Cell{IsMined: true, Flag: FlagRed}, state: Lose, want: OutcomeFlaggedMine},
+		{name: "Wrong flag", cell: Cell{Flag: FlagRed}, state: Lose, want: OutcomeWrongFlag},
+		{name: "Wrong count", cell: Cell{IsMined: true, Mines: 2, Flag: FlagRed, FlagCount: 1}, state: Lose, want: OutcomeWrongFlag},
+		{name: "Unflagged mine", cell: Cell{IsMined: true, Flag: FlagRed, AutoFlagged: true}, state: Won, want: OutcomeUnflaggedMine},
+		{name: "Safe", cell: Cell{IsOpen: true, MinesAround: 1}, state: Won},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cell.outcome(tt.state))
+		})
+	}
+}
+
+func TestGame_PlayerView_Lost(t *testing.T) {
+	// * . *
+	// . . .
+	// . . *
+	game := newMinedGame(3, 3, 0, 2, 8)
+	game.MarkRed(&CellRequest{Row: 1, Column: 2})
+	game.MarkRed(&CellRequest{Row: 3, Column: 3})
+	game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+
+	cells := game.PlayerView().Board.Cells
+
+	assert.Equal(t, Lose, game.State)
+	assert.Equal(t, OutcomeExploded, cells[0].Outcome)
+	assert.Equal(t, OutcomeWrongFlag, cells[1].Outcome)
+	assert.Equal(t, OutcomeMine, cells[2].Outcome)
+	assert.Equal(t, OutcomeFlaggedMine, cells[8].Outcome)
+	assert.Empty(t, cells[4].Outcome)
+}
+
+func TestGame_PlayerView_Won(t *testing.T) {
+	// * . . .
+	// . . . .
+	// . . . .
+	// . . . *
+	game := newMinedGame(4, 4, 0, 15)
+	game.MarkRed(&CellRequest{Row: 1, Column: 1})
+	game.UncoverCell(&CellRequest{Row: 1, Column: 4})
+
+	cells := game.PlayerView().Board.Cells
+
+	// the mines the player did not flag are flagged for them
+	assert.Equal(t, Won, game.State)
+	assert.Equal(t, 0, game.Board.MinesRemaining)
+	assert.Equal(t, FlagRed, cells[15].Flag)
+	assert.Equal(t, OutcomeUnflaggedMine, cells[15].Outcome)
+	assert.Equal(t, OutcomeFlaggedMine, cells[0].Outcome)
+}
+
+func TestGame_PlayerView_EndlessLost(t *testing.T) {
+	game := newEndlessGame(40, 1)
+	game.UncoverEndless(&CellRequest{}, nil)
+	var mine *CellRequest
+	for _, chunk := range game.Endless.Chunks {
+		for cellIndex, cell := range chunk.Cells {
+			if cell.IsMined && mine == nil {
+				mine = &CellRequest{Row: chunk.Row*ChunkSize + cellIndex/ChunkSize, Column: chunk.Column*ChunkSize + cellIndex%ChunkSize}
+			}
+		}
+	}
+
+	game.UncoverEndless(mine, nil)
+
+	exploded := 0
+	for _, chunk := range game.PlayerView().Endless.Chunks {
+		for _, cell := range chunk.Cells {
+			if cell.Outcome == OutcomeExploded {
+				exploded++
+			}
+		}
+	}
+	assert.Equal(t, Lose, game.State)
+	assert.Equal(t, 1, exploded)
+}
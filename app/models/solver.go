@@ -0,0 +1,212 @@
+package models
+
+import "time"
+
+type SolverRule string
+
+const (
+	// RuleSingle is a number that alone decides all the covered cells around it
+	RuleSingle SolverRule = "single"
+	// RulePair is two overlapping numbers that decide the cells only one of them touches
+	RulePair SolverRule = "pair"
+	// RuleCount is the number of mines left on the board, deciding every covered cell at once
+	RuleCount SolverRule = "count"
+)
+
+// Deduction is a cell the visible board proves to be safe or mined, with the open numbers that prove it
+type Deduction struct {
+	CellIndex   int
+	IsMined     bool
+	Rule        SolverRule
+	Constraints []int
+}
+
+// constraint is what an open number tells about the undecided cells around it
+type constraint struct {
+	cellIndex int
+	cells     []int
+	mines     int
+}
+
+// solver deduces safe and mined cells looking only at what a player sees: open cells and their numbers.
+// Flags are not trusted since the player may have placed them wrong, the mines it proved are kept in knownMines.
+type solver struct {
+	board      *Board
+	knownMines map[int]bool
+}
+
+// newSolver starts from the exploded mines, the player sees them as well as the open numbers
+func newSolver(board *Board) *solver {
+	s := &solver{
+		board:      board,
+		knownMines: make(map[int]bool),
+	}
+	for cellIndex, cell := range board.Cells {
+		if cell.Exploded {
+			s.knownMines[cellIndex] = true
+		}
+	}
+	return s
+}
+
+func (s *solver) isUndecided(cellIndex int) bool {
+	return !s.board.Cells[cellIndex].IsOpen && !s.board.isVoid(cellIndex) && !s.knownMines[cellIndex]
+}
+
+func (s *solver) constraints() []*constraint {
+	var constraints []*constraint
+	adjacentCells := make([]int, 0, 26)
+	for cellIndex, cell := range s.board.Cells {
+		if !cell.IsOpen {
+			continue
+		}
+		c := &constraint{cellIndex: cellIndex, mines: cell.MinesAround}
+		adjacentCells = s.board.appendAdjacentCells(adjacentCells[:0], cellIndex)
+		for _, adjacent := range adjacentCells {
+			if s.knownMines[adjacent] {
+				c.mines--
+			} else if !s.board.Cells[adjacent].IsOpen {
+				c.cells = append(c.cells, adjacent)
+			}
+		}
+		if len(c.cells) > 0 {
+			constraints = append(constraints, c)
+		}
+	}
+	return constraints
+}
+
+// deduce returns the cells decided by the simplest rule that decides any, in the order they were found
+func (s *solver) deduce() []*Deduction {
+	var deductions []*Deduction
+	decided := make(map[int]bool)
+	add := func(cells []int, isMined bool, rule SolverRule, from ...*constraint) {
+		for _, cellIndex := range cells {
+			if decided[cellIndex] {
+				continue
+			}
+			decided[cellIndex] = true
+			deduction := &Deduction{CellIndex: cellIndex, IsMined: isMined, Rule: rule}
+			for _, c := range from {
+				deduction.Constraints = append(deduction.Constraints, c.cellIndex)
+			}
+			deductions = append(deductions, deduction)
+		}
+	}
+
+	constraints := s.constraints()
+	for _, c := range constraints {
+		if c.mines == 0 {
+			add(c.cells, false, RuleSingle, c)
+		} else if c.mines == len(c.cells) {
+			add(c.cells, true, RuleSingle, c)
+		}
+	}
+	if len(deductions) > 0 {
+		return deductions
+	}
+
+	constraintsByCell := make(map[int][]*constraint)
+	for _, c := range constraints {
+		for _, cellIndex := range c.cells {
+			constraintsByCell[cellIndex] = append(constraintsByCell[cellIndex], c)
+		}
+	}
+	for _, a := range constraints {
+		compared := make(map[*constraint]bool)
+		for _, cellIndex := range a.cells {
+			for _, b := range constraintsByCell[cellIndex] {
+				if b == a || compared[b] {
+					continue
+				}
+				compared[b] = true
+
+				onlyA, onlyB := difference(a.cells, b.cells), difference(b.cells, a.cells)
+				if len(onlyB) == 0 {
+					continue
+				}
+				// the mines of a that are forced into, and that fit in, the cells both numbers touch
+				minShared := max(0, a.mines-len(onlyA))
+				maxShared := min(a.mines, len(a.cells)-len(onlyA))
+				if b.mines <= minShared {
+					add(onlyB, false, RulePair, a, b)
+				} else if b.mines-maxShared == len(onlyB) {
+					add(onlyB, true, RulePair, a, b)
+				}
+			}
+		}
+	}
+	if len(deductions) > 0 {
+		return deductions
+	}
+
+	var undecided []int
+	for cellIndex := range s.board.Cells {
+		if s.isUndecided(cellIndex) {
+			undecided = append(undecided, cellIndex)
+		}
+	}
+	minesLeft := s.board.Mines - len(s.knownMines)
+	if minesLeft == 0 {
+		add(undecided, false, RuleCount)
+	} else if minesLeft == len(undecided) {
+		add(undecided, true, RuleCount)
+	}
+	return deductions
+}
+
+// solvableFrom plays a copy of the board from the first cell and tells whether the solver clears it without guessing.
+// A board not cleared by the deadline is taken as not solvable.
+func (board *Board) solvableFrom(firstCellIndex int, deadline time.Time) bool {
+	simulation := board.clone()
+	simulation.Reveal(firstCellIndex)
+	s := newSolver(simulation)
+	for simulation.OpenCells+simulation.Mines < simulation.playableCells() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		deductions := s.deduce()
+		if len(deductions) == 0 {
+			return false
+		}
+		for _, deduction := range deductions {
+			if deduction.IsMined {
+				s.knownMines[deduction.CellIndex] = true
+			} else {
+				simulation.Reveal(deduction.CellIndex)
+			}
+		}
+	}
+	return true
+}
+
+func difference(cells []int, others []int) []int {
+	var result []int
+	for _, cellIndex := range cells {
+		found := false
+		for _, other := range others {
+			if other == cellIndex {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, cellIndex)
+		}
+	}
+	return result
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
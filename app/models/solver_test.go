@@ -0,0 +1,126 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSolver_deduce(t *testing.T) {
+	tests := []struct {
+		name           string
+		rows           int
+		columns        int
+		mines          []int
+		open           []int
+		wantRule       SolverRule
+		wantDeductions map[int]bool
+	}{
+		{
+			// 1 * .
+			name:           "Success - single number decides its only covered neighbour",
+			rows:           1,
+			columns:        3,
+			mines:          []int{1},
+			open:           []int{0},
+			wantRule:       RuleSingle,
+			wantDeductions: map[int]bool{1: true},
+		},
+		{
+			// . * . *
+			// 1 1 2 .
+			name:           "Success - overlapping numbers decide the cells only one touches",
+			rows:           2,
+			columns:        4,
+			mines:          []int{1, 3},
+			open:           []int{4, 5, 6},
+			wantRule:       RulePair,
+			wantDeductions: map[int]bool{2: false},
+		},
+		{
+			// . *
+			// 1 1
+			name:    "Success - nothing to deduce on a fifty-fifty",
+			rows:    2,
+			columns: 2,
+			mines:   []int{2},
+			open:    []int{0, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newMinedGame(tt.rows, tt.columns, tt.mines...)
+			for _, open := range tt.open {
+				game.Board.Cells[open].IsOpen = true
+			}
+
+			deductions := newSolver(game.Board).deduce()
+
+			assert.Len(t, deductions, len(tt.wantDeductions))
+			for _, deduction := range deductions {
+				assert.Equal(t, tt.wantRule, deduction.Rule)
+				assert.Equal(t, tt.wantDeductions[deduction.CellIndex], deduction.IsMined)
+				assert.NotEmpty(t, deduction.Constraints)
+			}
+		})
+	}
+}
+
+func TestSolver_deduce_Count(t *testing.T) {
+	// 0 1 * . . with the only mine already proved
+	game := newMinedGame(1, 5, 2)
+	for _, open := range []int{0, 1} {
+		game.Board.Cells[open].IsOpen = true
+	}
+	s := newSolver(game.Board)
+	s.knownMines[2] = true
+
+	deductions := s.deduce()
+
+	assert.Len(t, deductions, 2)
+	for i, deduction := range deductions {
+		assert.Equal(t, RuleCount, deduction.Rule)
+		assert.Equal(t, 3+i, deduction.CellIndex)
+		assert.False(t, deduction.IsMined)
+		assert.Empty(t, deduction.Constraints)
+	}
+}
+
+func TestBoard_fillNoGuessMines(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		game := newSeededGame(16, 16, 40, FirstClickSafeOpening, seed)
+		firstCellIndex := game.Board.calculateCell(&CellRequest{Row: 8, Column: 8})
+
+		solved := game.Board.fillNoGuessMines(game.NewRandom(), firstCellIndex, time.Now().Add(time.Minute))
+
+		assert.True(t, solved)
+		assert.False(t, game.Board.PendingMines)
+		assert.Len(t, minedCells(game.Board), 40)
+		assert.True(t, game.Board.solvableFrom(firstCellIndex, time.Now().Add(time.Minute)))
+		assert.True(t, game.Board.NoGuessAttempts >= 1)
+	}
+}
+
+func TestBoard_fillNoGuessMines_Deadline(t *testing.T) {
+	game := newSeededGame(16, 30, 170, FirstClickSafeOpening, 1)
+	firstCellIndex := game.Board.calculateCell(&CellRequest{Row: 8, Column: 15})
+
+	solved := game.Board.fillNoGuessMines(rand.New(rand.NewSource(1)), firstCellIndex, time.Now().Add(-time.Second))
+
+	// the attempt itself stops at the deadline, the layout drawn is kept
+	assert.Equal(t, 1, game.Board.NoGuessAttempts)
+	assert.False(t, solved)
+	assert.Len(t, minedCells(game.Board), 170)
+}
+
+func TestGame_UncoverCell_NoGuess(t *testing.T) {
+	game := newSeededGame(9, 9, 10, FirstClickSafeOpening, 3)
+	game.Board.NoGuess = true
+
+	game.UncoverCell(&CellRequest{Row: 5, Column: 5})
+
+	assert.False(t, game.NoGuessFallback)
+	assert.NotEqual(t, Lose, game.State)
+	assert.True(t, game.Board.solvableFrom(game.Board.calculateCell(&CellRequest{Row: 5, Column: 5}), time.Now().Add(time.Minute)))
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type GameAction string
+
+const (
+	ActionUncover      GameAction = "uncover"
+	ActionChord        GameAction = "chord"
+	ActionMarkRed      GameAction = "mark-red"
+	ActionMarkQuestion GameAction = "mark-question"
+	ActionUnmark       GameAction = "unmark"
+	ActionCycleMark    GameAction = "cycle-mark"
+	ActionHint         GameAction = "hint"
+	ActionPause        GameAction = "pause"
+	ActionResume       GameAction = "resume"
+	ActionUndo         GameAction = "undo"
+	ActionRedo         GameAction = "redo"
+)
+
+// LossReason tells how a game was lost
+type LossReason string
+
+const (
+	LossReasonMine    LossReason = "mine"
+	LossReasonTimeout LossReason = "timeout"
+)
+
+// transitions are the actions each state allows and the state each one leads to. A move leaves the game
+// in play and then its mode decides whether it was won or lost, finished games allow nothing but undoing
+// the loss, which the mode of the game may still refuse. Undo and redo put back the state the move left.
+// Resuming a game in play does nothing, so a client may always resume the game it loads.
+var transitions = map[StateGame]map[GameAction]StateGame{
+	Playing: {
+		ActionUncover:      Playing,
+		ActionChord:        Playing,
+		ActionMarkRed:      Playing,
+		ActionMarkQuestion: Playing,
+		ActionUnmark:       Playing,
+		ActionCycleMark:    Playing,
+		ActionHint:         Playing,
+		ActionPause:        Paused,
+		ActionResume:       Playing,
+		ActionUndo:         Playing,
+		ActionRedo:         Playing,
+	},
+	Paused: {
+		ActionResume: Playing,
+	},
+	Won: {},
+	Lose: {
+		ActionUndo: Lose,
+	},
+}
+
+// ErrForeignGame is returned when a player asks for the game of another player while it is in play
+var ErrForeignGame = errors.New("the game belongs to another user, it can only be seen once it is finished")
+
+// ErrNotAvailable is returned when the board of the game does not have the action, whatever its state
+var ErrNotAvailable = errors.New("not available")
+
+// TransitionError is returned when the state of the game does not allow the action
+type TransitionError struct {
+	State  StateGame
+	Action GameAction
+}
+
+func (err *TransitionError) Error() string {
+	return fmt.Sprintf("the game is %s, the action %s is not allowed", err.State, err.Action)
+}
+
+// Transition checks the state of the game allows the action and moves the game to the state the action leads to,
+// the clock of the game is started, paused and resumed at now
+func (game *Game) Transition(action GameAction, now time.Time) error {
+	next, ok := transitions[game.State][action]
+	if !ok {
+		return &TransitionError{State: game.State, Action: action}
+	}
+	previous := game.State
+	game.State = next
+	game.track(action, previous, now)
+	return nil
+}
+
+// Allows checks the state of the game allows the action, without moving the game
+func (game *Game) Allows(action GameAction) error {
+	if _, ok := transitions[game.State][action]; !ok {
+		return &TransitionError{State: game.State, Action: action}
+	}
+	return nil
+}
+
+func (state StateGame) String() string {
+	switch state {
+	case Playing:
+		return "playing"
+	case Paused:
+		return "paused"
+	case Won:
+		return "won"
+	case Lose:
+		return "lost"
+	}
+	return fmt.Sprintf("in the unknown state %d", int(state))
+}
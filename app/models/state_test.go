@@ -0,0 +1,45 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGame_Transition(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     StateGame
+		action    GameAction
+		wantState StateGame
+		wantErr   bool
+	}{
+		{name: "Success - uncover a game in play", state: Playing, action: ActionUncover, wantState: Playing},
+		{name: "Success - pause a game in play", state: Playing, action: ActionPause, wantState: Paused},
+		{name: "Success - resume a paused game", state: Paused, action: ActionResume, wantState: Playing},
+		{name: "Success - resume a game in play", state: Playing, action: ActionResume, wantState: Playing},
+		{name: "Success - undo a lost game", state: Lose, action: ActionUndo, wantState: Lose},
+		{name: "Error - redo a lost game", state: Lose, action: ActionRedo, wantState: Lose, wantErr: true},
+		{name: "Error - mark a paused game", state: Paused, action: ActionMarkRed, wantState: Paused, wantErr: true},
+		{name: "Error - pause a paused game", state: Paused, action: ActionPause, wantState: Paused, wantErr: true},
+		{name: "Error - pause a won game", state: Won, action: ActionPause, wantState: Won, wantErr: true},
+		{name: "Error - resume a lost game", state: Lose, action: ActionResume, wantState: Lose, wantErr: true},
+		{name: "Error - uncover a lost game", state: Lose, action: ActionUncover, wantState: Lose, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := &Game{State: tt.state}
+
+			err := game.Transition(tt.action, time.Now())
+
+			assert.Equal(t, tt.wantState, game.State)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.IsType(t, &TransitionError{}, err)
+			assert.Equal(t, tt.state, err.(*TransitionError).State)
+			assert.Equal(t, tt.action, err.(*TransitionError).Action)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// TimeUp tells whether a timed game in play went past its limit and has to be lost
+func (game *Game) TimeUp(now time.Time) bool {
+	return game.State == Playing && game.TimeLimitMs > 0 && game.Elapsed(now) >= game.timeLimit()
+}
+
+// Expire loses a timed game in play once the time played goes past its limit and tells whether it did.
+// The game ends when the time ran out and not when it is noticed, so a game expired late keeps the right time.
+func (game *Game) Expire(now time.Time) bool {
+	if !game.TimeUp(now) {
+		return false
+	}
+
+	endedAt := now.Add(game.timeLimit() - game.Elapsed(now))
+	game.State = Lose
+	game.LossReason = LossReasonTimeout
+	game.EndedAt = &endedAt
+	game.ElapsedMs = game.TimeLimitMs
+	return true
+}
+
+func (game *Game) timeLimit() time.Duration {
+	return time.Duration(game.TimeLimitMs) * time.Millisecond
+}
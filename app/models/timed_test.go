@@ -0,0 +1,57 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGame_Expire(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeLimitMs int64
+		now         int
+		wantExpired bool
+		wantEndedAt int
+	}{
+		{name: "Success - time left", timeLimitMs: 30000, now: 35},
+		{name: "Success - expired on the move", timeLimitMs: 30000, now: 40, wantExpired: true, wantEndedAt: 40},
+		{name: "Success - expired late by the sweeper", timeLimitMs: 30000, now: 90, wantExpired: true, wantEndedAt: 40},
+		{name: "Success - not timed", now: 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// played from 0 to 5 and from 15 on, 30 seconds are played at 40
+			game := newMinedGame(3, 3, 0)
+			game.TimeLimitMs = tt.timeLimitMs
+			game.Transition(ActionUncover, at(0))
+			game.Transition(ActionPause, at(5))
+			game.Transition(ActionResume, at(15))
+
+			expired := game.Expire(at(tt.now))
+
+			assert.Equal(t, tt.wantExpired, expired)
+			if !tt.wantExpired {
+				assert.Equal(t, Playing, game.State)
+				assert.Nil(t, game.EndedAt)
+				return
+			}
+			assert.Equal(t, Lose, game.State)
+			assert.Equal(t, LossReasonTimeout, game.LossReason)
+			assert.Equal(t, at(tt.wantEndedAt), *game.EndedAt)
+			game.UpdateClock(at(tt.now))
+			assert.Equal(t, tt.timeLimitMs, game.ElapsedMs)
+		})
+	}
+}
+
+func TestGame_Expire_NotStarted(t *testing.T) {
+	game := newMinedGame(3, 3, 0)
+	game.TimeLimitMs = 1000
+
+	assert.False(t, game.Expire(at(60)))
+
+	game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+	assert.Equal(t, Lose, game.State)
+	assert.Equal(t, LossReasonMine, game.LossReason)
+	assert.False(t, game.Expire(at(60)))
+}
@@ -0,0 +1,122 @@
+package models
+
+type TopologyType string
+
+const (
+	TopologyFlat  TopologyType = "flat"
+	TopologyTorus TopologyType = "torus"
+)
+
+// Topology decides which cells touch each other, void cells touch none. Every rule that looks around a cell, counting mines,
+// flooding or solving, goes through the topology of the board.
+type Topology interface {
+	// AppendNeighbours appends to the slice the cells around the cell, each one once and never the cell itself
+	AppendNeighbours(board *Board, neighbours []int, cellIndex int) []int
+}
+
+var topologies = map[TopologyType]Topology{
+	TopologyFlat:  flatTopology{},
+	TopologyTorus: torusTopology{},
+}
+
+// flatTopology is the classic board, the cells on the edges have fewer neighbours
+type flatTopology struct{}
+
+func (flatTopology) AppendNeighbours(board *Board, neighbours []int, cellIndex int) []int {
+	layer, row, column := board.cellCoordinates(cellIndex)
+	offsets := board.layout().Offsets(row)
+	for _, layerStep := range board.layerSteps() {
+		neighbourLayer := layer + layerStep
+		if neighbourLayer < 0 || neighbourLayer >= board.layerCount() {
+			continue
+		}
+		if neighbour := board.cellIndex(neighbourLayer, row, column); layerStep != 0 && !board.isVoid(neighbour) {
+			neighbours = append(neighbours, neighbour)
+		}
+		for _, offset := range offsets {
+			neighbourRow, neighbourColumn := row+offset[0], column+offset[1]
+			if neighbourRow < 0 || neighbourRow >= board.Rows || neighbourColumn < 0 || neighbourColumn >= board.Columns {
+				continue
+			}
+			if neighbour := board.cellIndex(neighbourLayer, neighbourRow, neighbourColumn); !board.isVoid(neighbour) {
+				neighbours = append(neighbours, neighbour)
+			}
+		}
+	}
+	return neighbours
+}
+
+// torusTopology wraps the edges around, leaving the right edge lands on the left one and the bottom edge on the top one.
+// On 3D boards the last layer touches the first one too.
+// Hex boards need an even number of rows to wrap, so the rows keep alternating across the edge.
+type torusTopology struct{}
+
+func (torusTopology) AppendNeighbours(board *Board, neighbours []int, cellIndex int) []int {
+	start := len(neighbours)
+	layer, row, column := board.cellCoordinates(cellIndex)
+	offsets := board.layout().Offsets(row)
+	layers := board.layerCount()
+	for _, layerStep := range board.layerSteps() {
+		neighbourLayer := (layer + layerStep + layers) % layers
+		for i := -1; i < len(offsets); i++ {
+			// the step -1 is the cell straight across on the layers above and below
+			neighbourRow, neighbourColumn := row, column
+			if i >= 0 {
+				neighbourRow = (row + offsets[i][0] + board.Rows) % board.Rows
+				neighbourColumn = (column + offsets[i][1] + board.Columns) % board.Columns
+			}
+			neighbour := board.cellIndex(neighbourLayer, neighbourRow, neighbourColumn)
+			// on boards narrower than three cells different steps wrap to the same cell
+			if neighbour == cellIndex || board.isVoid(neighbour) || containsCell(neighbours[start:], neighbour) {
+				continue
+			}
+			neighbours = append(neighbours, neighbour)
+		}
+	}
+	return neighbours
+}
+
+var (
+	flatLayerSteps = []int{0}
+	// on 3D boards the cells around are on the same layer and on the layers right above and below it
+	spaceLayerSteps = []int{0, -1, 1}
+)
+
+func (board *Board) layerSteps() []int {
+	if board.layerCount() == 1 {
+		return flatLayerSteps
+	}
+	return spaceLayerSteps
+}
+
+func (board *Board) topology() Topology {
+	if topology, ok := topologies[board.Topology]; ok {
+		return topology
+	}
+	return flatTopology{}
+}
+
+func (board *Board) adjacentCells(cellIndex int) []int {
+	return board.appendAdjacentCells(make([]int, 0, 26), cellIndex)
+}
+
+func (board *Board) appendAdjacentCells(adjacentCells []int, cellIndex int) []int {
+	if board.isVoid(cellIndex) {
+		return adjacentCells
+	}
+	return board.topology().AppendNeighbours(board, adjacentCells, cellIndex)
+}
+
+func IsValidTopology(topology TopologyType) bool {
+	_, ok := topologies[topology]
+	return ok
+}
+
+func containsCell(cells []int, cellIndex int) bool {
+	for _, cell := range cells {
+		if cell == cellIndex {
+			return true
+		}
+	}
+	return false
+}
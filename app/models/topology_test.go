@@ -0,0 +1,109 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestTopology_AppendNeighbours(t *testing.T) {
+	tests := []struct {
+		name      string
+		topology  TopologyType
+		layout    LayoutType
+		layers    int
+		rows      int
+		columns   int
+		cellIndex int
+		want      []int
+	}{
+		{name: "Success - flat corner", topology: TopologyFlat, rows: 3, columns: 4, cellIndex: 0, want: []int{1, 4, 5}},
+		{name: "Success - flat middle", topology: TopologyFlat, rows: 3, columns: 4, cellIndex: 5, want: []int{0, 1, 2, 4, 6, 8, 9, 10}},
+		{name: "Success - saved board without topology is flat", rows: 3, columns: 4, cellIndex: 11, want: []int{6, 7, 10}},
+		{name: "Success - torus corner wraps to the other edges", topology: TopologyTorus, rows: 3, columns: 4, cellIndex: 0, want: []int{1, 3, 4, 5, 7, 8, 9, 11}},
+		{name: "Success - torus narrower than three cells", topology: TopologyTorus, rows: 2, columns: 3, cellIndex: 0, want: []int{1, 2, 3, 4, 5}},
+		{name: "Success - hex even row", layout: LayoutHex, rows: 3, columns: 4, cellIndex: 9, want: []int{4, 5, 8, 10}},
+		{name: "Success - hex odd row", layout: LayoutHex, rows: 3, columns: 4, cellIndex: 6, want: []int{2, 3, 5, 7, 10, 11}},
+		{name: "Success - hex corner", layout: LayoutHex, rows: 3, columns: 4, cellIndex: 0, want: []int{1, 4}},
+		{name: "Success - hex torus corner", layout: LayoutHex, topology: TopologyTorus, rows: 4, columns: 4, cellIndex: 0, want: []int{1, 3, 4, 7, 12, 15}},
+		{name: "Success - 3D middle touches the whole cube around", layers: 3, rows: 3, columns: 3, cellIndex: 13,
+			want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26}},
+		{name: "Success - 3D corner", layers: 3, rows: 3, columns: 3, cellIndex: 0, want: []int{1, 3, 4, 9, 10, 12, 13}},
+		{name: "Success - 3D torus narrower than three cells", topology: TopologyTorus, layers: 2, rows: 2, columns: 2, cellIndex: 0, want: []int{1, 2, 3, 4, 5, 6, 7}},
+		{name: "Success - 3D hex odd row", layout: LayoutHex, layers: 2, rows: 3, columns: 4, cellIndex: 6, want: []int{2, 3, 5, 7, 10, 11, 14, 15, 17, 18, 19, 22, 23}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := &Board{Rows: tt.rows, Columns: tt.columns, Topology: tt.topology, Layout: tt.layout, Layers: tt.layers}
+
+			neighbours := board.adjacentCells(tt.cellIndex)
+
+			sort.Ints(neighbours)
+			assert.Equal(t, tt.want, neighbours)
+		})
+	}
+}
+
+func TestGame_UncoverCell_Torus(t *testing.T) {
+	// the mine in the middle is next to every cell once the edges wrap
+	game := newMinedGame(3, 3, 4)
+	game.Board.Topology = TopologyTorus
+	game.Board.countMinesAround()
+
+	openedCells := game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+
+	assert.Equal(t, []int{0}, openedCells)
+	for cellIndex, cell := range game.Board.Cells {
+		if cellIndex != 4 {
+			assert.Equal(t, 1, cell.MinesAround)
+		}
+	}
+}
+
+func TestGame_UncoverCell_Hex(t *testing.T) {
+	game := newSeededGame(8, 8, 10, FirstClickSafeOpening, 5)
+	game.Board.Layout = LayoutHex
+
+	game.UncoverCell(&CellRequest{Row: 4, Column: 4})
+	for hint := game.Hint(); hint.Found && game.State == Playing; hint = game.Hint() {
+		if hint.Action == HintUncover {
+			game.UncoverCell(&CellRequest{Row: hint.Row, Column: hint.Column})
+		} else {
+			game.MarkRed(&CellRequest{Row: hint.Row, Column: hint.Column})
+		}
+	}
+
+	assert.NotEqual(t, Lose, game.State)
+	for cellIndex, cell := range game.Board.Cells {
+		assert.True(t, len(game.Board.adjacentCells(cellIndex)) <= 6)
+		if cell.IsOpen {
+			assert.False(t, cell.IsMined)
+		}
+	}
+}
+
+func TestGame_UncoverCell_Space(t *testing.T) {
+	game := &Game{State: Playing, Seed: 2}
+	game.Board = &Board{Rows: 4, Columns: 4, Layers: 4, Mines: 6, FirstClick: FirstClickSafeOpening, Cells: make([]*Cell, 64)}
+	game.Board.InitBoard(game.NewRandom())
+
+	game.UncoverCell(&CellRequest{Layer: 2, Row: 2, Column: 2})
+	for hint := game.Hint(); hint.Found && game.State == Playing; hint = game.Hint() {
+		assert.NotZero(t, hint.Layer)
+		cell := &CellRequest{Layer: hint.Layer, Row: hint.Row, Column: hint.Column}
+		if hint.Action == HintUncover {
+			game.UncoverCell(cell)
+		} else {
+			game.MarkRed(cell)
+		}
+	}
+
+	assert.NotEqual(t, Lose, game.State)
+	assert.Len(t, minedCells(game.Board), 6)
+	for cellIndex, cell := range game.Board.Cells {
+		assert.Equal(t, cellIndex, game.Board.calculateCell(game.Board.cellPosition(cellIndex)))
+		if cell.IsOpen {
+			assert.False(t, cell.IsMined)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNothingToUndo = errors.New("there is no move to undo")
+	ErrNothingToRedo = errors.New("there is no move to redo")
+	ErrUndoLimit     = errors.New("the game has no undo left")
+	ErrUndoLoss      = errors.New("the loss can't be undone in this mode")
+)
+
+// Move is a change of the board that can be undone. The opened cells only change on IsOpen,
+// the few other cells the move may change are kept as they were before and after it.
+type Move struct {
+	Action GameAction      `bson:"action" json:"action"`
+	Opened []int           `bson:"opened" json:"opened"`
+	Before []*CellSnapshot `bson:"before" json:"before"`
+	After  []*CellSnapshot `bson:"after" json:"after"`
+	// StateBefore and StateAfter are the parts of the game the move changed besides the cells
+	StateBefore *MoveState `bson:"state_before" json:"stateBefore"`
+	StateAfter  *MoveState `bson:"state_after" json:"stateAfter"`
+}
+
+type CellSnapshot struct {
+	Index int  `bson:"index" json:"index"`
+	Cell  Cell `bson:"cell" json:"cell"`
+}
+
+type MoveState struct {
+	State         StateGame  `bson:"state" json:"state"`
+	LossReason    LossReason `bson:"loss_reason" json:"lossReason,omitempty"`
+	EndedAt       *time.Time `bson:"ended_at" json:"endedAt,omitempty"`
+	OpenCells     int        `bson:"open_cells" json:"openCells"`
+	Lives         int        `bson:"lives" json:"lives"`
+	ExplodedMines int        `bson:"exploded_mines" json:"explodedMines"`
+}
+
+// Undo reverses the last move, the flood it opened included, and keeps it to be redone.
+// A move that lost the game is only undone when the mode allows it.
+func (game *Game) Undo() error {
+	if game.undosLeft() <= 0 {
+		return ErrUndoLimit
+	}
+	if len(game.Moves) == 0 {
+		return ErrNothingToUndo
+	}
+	if game.State == Lose && !game.lossUndoable() {
+		return ErrUndoLoss
+	}
+	move := game.Moves[len(game.Moves)-1]
+
+	for _, cellIndex := range move.Opened {
+		game.Board.Cells[cellIndex].IsOpen = false
+	}
+	game.restore(move.Before, move.StateBefore)
+	game.Moves = game.Moves[:len(game.Moves)-1]
+	game.Undone = append(game.Undone, move)
+	game.UndosUsed++
+	return nil
+}
+
+// Finished tells whether the game is over for good: won, or lost with no undo able to take the loss back.
+// Only then may the player see the whole board.
+func (game *Game) Finished() bool {
+	return game.State == Won || (game.State == Lose && !game.lossUndoable())
+}
+
+// lossUndoable tells whether the last move lost the game and the mode lets it be undone with an undo left
+func (game *Game) lossUndoable() bool {
+	if game.State != Lose || game.Board == nil || game.undosLeft() <= 0 || len(game.Moves) == 0 {
+		return false
+	}
+	return game.Moves[len(game.Moves)-1].StateAfter.State == Lose && game.Board.mode().UndoLoss()
+}
+
+// Redo makes again the last move undone, redoing does not use an undo
+func (game *Game) Redo() error {
+	if len(game.Undone) == 0 {
+		return ErrNothingToRedo
+	}
+	move := game.Undone[len(game.Undone)-1]
+
+	for _, cellIndex := range move.Opened {
+		game.Board.Cells[cellIndex].IsOpen = true
+	}
+	game.restore(move.After, move.StateAfter)
+	game.Undone = game.Undone[:len(game.Undone)-1]
+	game.Moves = append(game.Moves, move)
+	return nil
+}
+
+// record makes the move on the cell and keeps what it changed, moves are only kept while the game has undos left.
+// A new move drops the moves undone, they can't be redone any more.
+func (game *Game) record(action GameAction, cellIndex int, move func() []int) []int {
+	game.Undone = nil
+	if game.undosLeft() <= 0 {
+		opened := move()
+		game.autoFlagged = nil
+		return opened
+	}
+
+	// only the cell and its neighbours change other than by opening: flags, exploded mines and chords
+	watched := append([]int{cellIndex}, game.Board.adjacentCells(cellIndex)...)
+	before := game.snapshotCells(watched)
+	stateBefore := game.moveState()
+	game.autoFlagged = nil
+	opened := move()
+	after := game.snapshotCells(watched)
+	stateAfter := game.moveState()
+
+	recorded := &Move{Action: action, Opened: opened, StateBefore: stateBefore, StateAfter: stateAfter}
+	isWatched := make(map[int]bool, len(watched))
+	for i, watchedIndex := range watched {
+		isWatched[watchedIndex] = true
+		if before[i].Cell != after[i].Cell {
+			recorded.Before = append(recorded.Before, before[i])
+			recorded.After = append(recorded.After, after[i])
+		}
+	}
+	// and the mines a winning move flagged, anywhere on the board
+	for _, flagged := range game.autoFlagged {
+		if !isWatched[flagged.Index] {
+			recorded.Before = append(recorded.Before, flagged)
+			recorded.After = append(recorded.After, game.snapshotCells([]int{flagged.Index})...)
+		}
+	}
+	game.autoFlagged = nil
+	if len(recorded.Opened) == 0 && len(recorded.Before) == 0 && *stateBefore == *stateAfter {
+		return opened
+	}
+
+	game.Moves = append(game.Moves, recorded)
+	if extra := len(game.Moves) - game.undosLeft(); extra > 0 {
+		game.Moves = game.Moves[extra:]
+	}
+	return opened
+}
+
+// forgetMoves drops every move kept, the board they were made on is gone
+func (game *Game) forgetMoves() {
+	game.Moves, game.Undone = nil, nil
+}
+
+func (game *Game) undosLeft() int {
+	return game.UndoLimit - game.UndosUsed
+}
+
+func (game *Game) snapshotCells(cellIndexes []int) []*CellSnapshot {
+	snapshots := make([]*CellSnapshot, len(cellIndexes))
+	for i, cellIndex := range cellIndexes {
+		snapshots[i] = &CellSnapshot{Index: cellIndex, Cell: *game.Board.Cells[cellIndex]}
+	}
+	return snapshots
+}
+
+func (game *Game) moveState() *MoveState {
+	return &MoveState{
+		State:         game.State,
+		LossReason:    game.LossReason,
+		EndedAt:       game.EndedAt,
+		OpenCells:     game.Board.OpenCells,
+		Lives:         game.Lives,
+		ExplodedMines: game.ExplodedMines,
+	}
+}
+
+func (game *Game) restore(cells []*CellSnapshot, state *MoveState) {
+	for _, snapshot := range cells {
+		*game.Board.Cells[snapshot.Index] = snapshot.Cell
+	}
+	game.State = state.State
+	game.LossReason = state.LossReason
+	game.EndedAt = state.EndedAt
+	game.Board.OpenCells = state.OpenCells
+	game.Lives = state.Lives
+	game.ExplodedMines = state.ExplodedMines
+	game.Board.countMinesRemaining()
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGame_Undo_Flood(t *testing.T) {
+	// * . . .
+	// . . . .
+	// . . . .
+	// . . . *
+	game := newMinedGame(4, 4, 0, 15)
+	game.UndoLimit = 5
+
+	game.MarkRed(&CellRequest{Row: 1, Column: 1})
+	opened := game.UncoverCell(&CellRequest{Row: 1, Column: 4})
+	assert.Equal(t, 14, len(opened))
+	assert.Equal(t, Won, game.State)
+
+	assert.NoError(t, game.Undo())
+	assert.Equal(t, Playing, game.State)
+	assert.Equal(t, 0, game.Board.OpenCells)
+	for _, cell := range game.Board.Cells {
+		assert.False(t, cell.IsOpen)
+	}
+	assert.Equal(t, FlagRed, game.Board.Cells[0].Flag)
+	// the mine flagged by the win is away from the cell uncovered
+	assert.Equal(t, FlagNone, game.Board.Cells[15].Flag)
+	assert.False(t, game.Board.Cells[15].AutoFlagged)
+	assert.Equal(t, 1, game.Board.MinesRemaining)
+
+	assert.NoError(t, game.Undo())
+	assert.Equal(t, FlagNone, game.Board.Cells[0].Flag)
+	assert.Equal(t, ErrNothingToUndo, game.Undo())
+
+	assert.NoError(t, game.Redo())
+	assert.NoError(t, game.Redo())
+	assert.Equal(t, Won, game.State)
+	assert.Equal(t, 14, game.Board.OpenCells)
+	assert.True(t, game.Board.Cells[15].AutoFlagged)
+	assert.Equal(t, ErrNothingToRedo, game.Redo())
+	assert.Equal(t, 2, game.UndosUsed)
+}
+
+func TestGame_Undo_NewMoveDropsRedo(t *testing.T) {
+	game := newMinedGame(3, 3, 0)
+	game.UndoLimit = 5
+
+	game.MarkRed(&CellRequest{Row: 1, Column: 1})
+	game.Undo()
+	game.MarkQuestion(&CellRequest{Row: 2, Column: 2})
+
+	assert.Equal(t, ErrNothingToRedo, game.Redo())
+	assert.Equal(t, FlagQuestion, game.Board.Cells[4].Flag)
+}
+
+func TestGame_Undo_Limit(t *testing.T) {
+	game := newMinedGame(3, 3, 0)
+	game.UndoLimit = 1
+
+	game.MarkRed(&CellRequest{Row: 1, Column: 1})
+	game.MarkRed(&CellRequest{Row: 1, Column: 2})
+	assert.Len(t, game.Moves, 1)
+
+	assert.NoError(t, game.Undo())
+	assert.Equal(t, FlagRed, game.Board.Cells[0].Flag)
+	assert.Equal(t, FlagNone, game.Board.Cells[1].Flag)
+	assert.Equal(t, ErrUndoLimit, game.Undo())
+}
+
+func TestGame_Undo_Loss(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    GameModeType
+		lives   int
+		wantErr error
+	}{
+		{name: "Success - practice", mode: GameModePractice, lives: 1},
+		{name: "Error - classic", mode: GameModeClassic, wantErr: ErrUndoLoss},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newMinedGame(3, 3, 0)
+			game.Board.Mode = tt.mode
+			game.Lives = tt.lives
+			game.UndoLimit = 3
+
+			game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+			assert.Equal(t, Lose, game.State)
+
+			err := game.Undo()
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				assert.Equal(t, Lose, game.State)
+				return
+			}
+			assert.Equal(t, Playing, game.State)
+			assert.Equal(t, tt.lives, game.Lives)
+			assert.Equal(t, 0, game.ExplodedMines)
+			assert.False(t, game.Board.Cells[0].Exploded)
+			assert.Empty(t, game.LossReason)
+		})
+	}
+}
+
+func TestGame_Undo_Chord(t *testing.T) {
+	// . . .
+	// . . .
+	// * . *
+	game := newMinedGame(3, 3, 6, 8)
+	game.Board.Mode = GameModePractice
+	game.Lives = 3
+	game.UndoLimit = 3
+	game.UncoverCell(&CellRequest{Row: 2, Column: 2})
+	game.MarkRed(&CellRequest{Row: 3, Column: 1})
+	game.MarkRed(&CellRequest{Row: 3, Column: 2})
+
+	game.ChordCell(&CellRequest{Row: 2, Column: 2})
+	assert.Equal(t, 2, game.Lives)
+	assert.True(t, game.Board.Cells[8].Exploded)
+
+	assert.NoError(t, game.Undo())
+	assert.Equal(t, 3, game.Lives)
+	assert.False(t, game.Board.Cells[8].Exploded)
+	assert.Equal(t, 1, game.Board.OpenCells)
+}
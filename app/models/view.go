@@ -0,0 +1,192 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// GameView is the game as its player may see it, the games are never returned as they are stored.
+// While the game is played the covered cells only show their flags and the seed, which lays the mines, is left out.
+// Once the game is finished for good the whole board is shown, each cell with its outcome.
+type GameView struct {
+	Id              primitive.ObjectID `json:"id,omitempty"`
+	Board           *BoardView         `json:"board,omitempty"`
+	Endless         *EndlessView       `json:"endless,omitempty"`
+	UserName        string             `json:"userName"`
+	State           StateGame          `json:"state"`
+	Seed            int64              `json:"seed,string,omitempty"`
+	NoGuessFallback bool               `json:"noGuessFallback"`
+	HintsUsed       int                `json:"hintsUsed"`
+	CreationAt      time.Time          `json:"createAt,omitempty"`
+	EndedAt         *time.Time         `json:"endedAt,omitempty"`
+	Lives           int                `json:"lives"`
+	ExplodedMines   int                `json:"explodedMines"`
+	Ranked          bool               `json:"ranked"`
+	StartedAt       *time.Time         `json:"startedAt,omitempty"`
+	Pauses          []*Pause           `json:"pauses,omitempty"`
+	ElapsedMs       int64              `json:"elapsedMs"`
+	TimeLimitMs     int64              `json:"timeLimitMs,omitempty"`
+	LossReason      LossReason         `json:"lossReason,omitempty"`
+	UndoLimit       int                `json:"undoLimit"`
+	UndosUsed       int                `json:"undosUsed"`
+	Version         int                `json:"version"`
+}
+
+type GameViewDto struct {
+	Data []*GameView `json:"data"`
+}
+
+type BoardView struct {
+	Rows            int          `json:"rows"`
+	Columns         int          `json:"columns"`
+	Layers          int          `json:"layers,omitempty"`
+	Mines           int          `json:"mines"`
+	OpenCells       int          `json:"openCells"`
+	FirstClick      FirstClick   `json:"firstClick"`
+	Topology        TopologyType `json:"topology"`
+	Layout          LayoutType   `json:"layout"`
+	PendingMines    bool         `json:"pendingMines"`
+	NoGuess         bool         `json:"noGuess"`
+	NoGuessAttempts int          `json:"noGuessAttempts"`
+	Mask            string       `json:"mask,omitempty"`
+	Voids           int          `json:"voids,omitempty"`
+	Mode            GameModeType `json:"mode,omitempty"`
+	MaxMinesPerCell int          `json:"maxMinesPerCell,omitempty"`
+	MinesRemaining  int          `json:"minesRemaining"`
+	Cells           []*CellView  `json:"cells"`
+}
+
+type EndlessView struct {
+	Mines  int          `json:"mines"`
+	Score  int          `json:"score"`
+	Chunks []*ChunkView `json:"chunks"`
+}
+
+type ChunkView struct {
+	Row    int         `json:"row"`
+	Column int         `json:"column"`
+	Cells  []*CellView `json:"cells"`
+}
+
+// CellView is a cell as its player may see it. MinesAround is only set on open cells, IsMined, Mines and
+// Outcome once the game is over. Exploded mines are known to the player and stay shown.
+type CellView struct {
+	IsOpen      bool        `json:"isOpen"`
+	Flag        FlagType    `json:"flag"`
+	FlagCount   int         `json:"flagCount,omitempty"`
+	Void        bool        `json:"void,omitempty"`
+	Exploded    bool        `json:"exploded,omitempty"`
+	MinesAround *int        `json:"minesAround,omitempty"`
+	IsMined     *bool       `json:"isMined,omitempty"`
+	Mines       int         `json:"mines,omitempty"`
+	Outcome     CellOutcome `json:"outcome,omitempty"`
+}
+
+// PlayerView returns what the player may see of the game, the whole board only once it is finished,
+// a loss the player may still undo keeps the board hidden
+func (game *Game) PlayerView() *GameView {
+	revealed := game.Finished()
+	view := &GameView{
+		Id:              game.Id,
+		UserName:        game.UserName,
+		State:           game.State,
+		NoGuessFallback: game.NoGuessFallback,
+		HintsUsed:       game.HintsUsed,
+		CreationAt:      game.CreationAt,
+		EndedAt:         game.EndedAt,
+		Lives:           game.Lives,
+		ExplodedMines:   game.ExplodedMines,
+		Ranked:          game.Ranked,
+		StartedAt:       game.StartedAt,
+		Pauses:          game.Pauses,
+		ElapsedMs:       game.ElapsedMs,
+		TimeLimitMs:     game.TimeLimitMs,
+		LossReason:      game.LossReason,
+		UndoLimit:       game.UndoLimit,
+		UndosUsed:       game.UndosUsed,
+		Version:         game.Version,
+	}
+	if revealed {
+		view.Seed = game.Seed
+	}
+	if game.Board != nil {
+		view.Board = game.Board.view(revealed, game.State)
+	}
+	if game.Endless != nil {
+		view.Endless = &EndlessView{
+			Mines:  game.Endless.Mines,
+			Score:  game.Endless.Score,
+			Chunks: chunkViews(game.Endless.Chunks, revealed, game.State),
+		}
+	}
+	return view
+}
+
+// PlayerView returns what the player may see of each game
+func (dto *GameDto) PlayerView() *GameViewDto {
+	views := &GameViewDto{Data: make([]*GameView, 0, len(dto.Data))}
+	for _, game := range dto.Data {
+		views.Data = append(views.Data, game.PlayerView())
+	}
+	return views
+}
+
+// view shows the board of a game in the state, the covered cells only once it is revealed
+func (board *Board) view(revealed bool, state StateGame) *BoardView {
+	return &BoardView{
+		Rows:            board.Rows,
+		Columns:         board.Columns,
+		Layers:          board.Layers,
+		Mines:           board.Mines,
+		OpenCells:       board.OpenCells,
+		FirstClick:      board.FirstClick,
+		Topology:        board.Topology,
+		Layout:          board.Layout,
+		PendingMines:    board.PendingMines,
+		NoGuess:         board.NoGuess,
+		NoGuessAttempts: board.NoGuessAttempts,
+		Mask:            board.Mask,
+		Voids:           board.Voids,
+		Mode:            board.Mode,
+		MaxMinesPerCell: board.MaxMinesPerCell,
+		MinesRemaining:  board.MinesRemaining,
+		Cells:           cellViews(board.Cells, revealed, state),
+	}
+}
+
+func chunkViews(chunks []*Chunk, revealed bool, state StateGame) []*ChunkView {
+	views := make([]*ChunkView, 0, len(chunks))
+	for _, chunk := range chunks {
+		views = append(views, &ChunkView{Row: chunk.Row, Column: chunk.Column, Cells: cellViews(chunk.Cells, revealed, state)})
+	}
+	return views
+}
+
+func cellViews(cells []*Cell, revealed bool, state StateGame) []*CellView {
+	views := make([]*CellView, len(cells))
+	for cellIndex, cell := range cells {
+		views[cellIndex] = cell.view(revealed, state)
+	}
+	return views
+}
+
+func (cell *Cell) view(revealed bool, state StateGame) *CellView {
+	view := &CellView{
+		IsOpen:    cell.IsOpen,
+		Flag:      cell.Flag,
+		FlagCount: cell.FlagCount,
+		Void:      cell.Void,
+		Exploded:  cell.Exploded,
+	}
+	if cell.IsOpen || revealed {
+		minesAround := cell.MinesAround
+		view.MinesAround = &minesAround
+	}
+	if revealed {
+		isMined := cell.IsMined
+		view.IsMined = &isMined
+		view.Mines = cell.Mines
+		view.Outcome = cell.outcome(state)
+	}
+	return view
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGame_PlayerView(t *testing.T) {
+	// * . .
+	// . . .
+	// . . *
+	game := newMinedGame(3, 3, 0, 8)
+	game.Seed = 7
+	game.UncoverCell(&CellRequest{Row: 2, Column: 2})
+	game.MarkRed(&CellRequest{Row: 1, Column: 1})
+
+	view := game.PlayerView()
+
+	assert.Zero(t, view.Seed)
+	assert.Equal(t, 2, *view.Board.Cells[4].MinesAround)
+	assert.Nil(t, view.Board.Cells[4].IsMined)
+	assert.Equal(t, FlagRed, view.Board.Cells[0].Flag)
+	assert.Nil(t, view.Board.Cells[0].MinesAround)
+	assert.Nil(t, view.Board.Cells[0].IsMined)
+	body, _ := json.Marshal(view)
+	assert.NotContains(t, string(body), "isMined")
+	assert.NotContains(t, string(body), "seed")
+
+	game.Unmark(&CellRequest{Row: 1, Column: 1})
+	game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+	view = game.PlayerView()
+
+	// once the game is lost the whole board is shown
+	assert.Equal(t, Lose, view.State)
+	assert.Equal(t, int64(7), view.Seed)
+	assert.True(t, *view.Board.Cells[8].IsMined)
+	assert.False(t, *view.Board.Cells[1].IsMined)
+	assert.Equal(t, 1, *view.Board.Cells[1].MinesAround)
+}
+
+func TestGame_PlayerView_Endless(t *testing.T) {
+	game := newEndlessGame(40, 1)
+	game.UncoverEndless(&CellRequest{}, nil)
+
+	view := game.PlayerView()
+
+	assert.Nil(t, view.Board)
+	assert.Len(t, view.Endless.Chunks, len(game.Endless.Chunks))
+	for chunkIndex, chunk := range view.Endless.Chunks {
+		for cellIndex, cell := range chunk.Cells {
+			assert.Nil(t, cell.IsMined)
+			assert.Equal(t, game.Endless.Chunks[chunkIndex].Cells[cellIndex].IsOpen, cell.MinesAround != nil)
+		}
+	}
+}
+
+func TestGameDto_PlayerView(t *testing.T) {
+	games := &GameDto{Data: []*Game{newMinedGame(3, 3, 0), newMinedGame(2, 2, 3)}}
+
+	views := games.PlayerView()
+
+	assert.Len(t, views.Data, 2)
+	assert.Nil(t, views.Data[1].Board.Cells[3].IsMined)
+}
+
+func TestGame_PlayerView_UndoableLoss(t *testing.T) {
+	tests := []struct {
+		name         string
+		undoLimit    int
+		wantRevealed bool
+	}{
+		{name: "Loss that may be undone", undoLimit: 3},
+		{name: "No undo left", undoLimit: 0, wantRevealed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newMinedGame(3, 3, 0, 8)
+			game.Board.Mode = GameModePractice
+			game.Lives = 1
+			game.UndoLimit = tt.undoLimit
+			game.Seed = 7
+			game.UncoverCell(&CellRequest{Row: 2, Column: 2})
+			game.UncoverCell(&CellRequest{Row: 1, Column: 1})
+
+			view := game.PlayerView()
+
+			assert.Equal(t, Lose, view.State)
+			assert.Equal(t, tt.wantRevealed, game.Finished())
+			assert.Equal(t, tt.wantRevealed, view.Board.Cells[8].IsMined != nil)
+			assert.Equal(t, tt.wantRevealed, view.Seed != 0)
+		})
+	}
+}
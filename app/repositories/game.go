@@ -2,46 +2,56 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/pedidosya/minesweeper-API/app/models"
 	"github.com/pedidosya/minesweeper-API/infrastructure"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"time"
 )
 
 type IGameRepository interface {
-	NewGame(board *models.Board, userName string) (interface{}, error)
-	PauseGame(gameId string) (bool, error)
-	ResumeGame(gameId string, userName string) (*models.Game, error)
+	NewGame(game *models.Game) (interface{}, error)
 	UpdateGame(gameId string, game *models.Game) error
 	GetGame(gameId string) (*models.Game, error)
 	FindGames(user string) (*models.GameDto, error)
+	FindTimedGames() ([]*models.Game, error)
+	GetChunk(gameId string, row int, column int) (*models.Chunk, error)
+	FindChunks(gameId string) ([]*models.Chunk, error)
+	SaveChunks(gameId string, chunks []*models.Chunk) error
+	AppendEvents(gameId string, events []*models.GameEvent) error
+	FindEvents(gameId string, afterSeq int) ([]*models.GameEvent, error)
 }
 
 const gameCollection string = "games"
 
+// eventCollection holds the events of the games, the games collection only keeps a snapshot of each game
+const eventCollection string = "game_events"
+
+// chunkCollection holds the chunks of endless games, one document per chunk a move touched
+const chunkCollection string = "chunks"
+
 type GameRepository struct {
 	dataBaseProvider infrastructure.IDataBaseProvider
 }
 
-func (gameRepository *GameRepository) NewGame(board *models.Board, userName string) (interface{}, error) {
-	newGame := &models.Game{
-		Board:      board,
-		UserName:   userName,
-		State:      models.Playing,
-		CreationAt: time.Now(),
-	}
+func (gameRepository *GameRepository) NewGame(game *models.Game) (interface{}, error) {
+	game.State = models.Playing
+	game.CreationAt = time.Now()
 
-	id, err := gameRepository.dataBaseProvider.Insert(gameCollection, newGame)
+	id, err := gameRepository.dataBaseProvider.Insert(gameCollection, game)
 	if err != nil {
 		return nil, err
 	}
 
-	newGame.Id = id.(primitive.ObjectID)
-	return newGame, nil
+	game.Id = id.(primitive.ObjectID)
+	return game, nil
 }
 
+// UpdateGame stores a snapshot of the game, the events after its version are applied on top of it when it is loaded
 func (gameRepository *GameRepository) UpdateGame(gameId string, game *models.Game) error {
 	objID, err := primitive.ObjectIDFromHex(gameId)
 	if err != nil {
@@ -50,32 +60,6 @@ func (gameRepository *GameRepository) UpdateGame(gameId string, game *models.Gam
 	return gameRepository.dataBaseProvider.ReplaceById(gameCollection, objID, game)
 }
 
-func (gameRepository *GameRepository) PauseGame(gameId string) (bool, error) {
-	objID, err := primitive.ObjectIDFromHex(gameId)
-	if err != nil {
-		return false, fmt.Errorf("not can create object_id: %v", err)
-	}
-
-	query := bson.M{}
-	newState := bson.M{}
-	newState["state"] = models.Paused
-	query["$set"] = newState
-
-	return gameRepository.dataBaseProvider.Update(gameCollection, objID, query)
-}
-
-func (gameRepository *GameRepository) ResumeGame(gameId string, userName string) (*models.Game, error) {
-	game, err := gameRepository.GetGame(gameId)
-	if err != nil {
-		return nil, 	err
-	}
-	if game.UserName != userName {
-		return nil, fmt.Errorf("the game belongs to another user")
-	}
-	gameRepository.resumeGame(gameId)
-	return game, nil
-}
-
 func (gameRepository *GameRepository) FindGames(user string) (*models.GameDto, error) {
 	// create empty map for query
 	query := bson.M{}
@@ -99,12 +83,38 @@ func (gameRepository *GameRepository) FindGames(user string) (*models.GameDto, e
 		if err := cur.Decode(&game); err != nil {
 			return nil, fmt.Errorf("error marshal from database: %v", err)
 		}
+		if err := gameRepository.replay(game); err != nil {
+			return nil, err
+		}
 		results.Data = append(results.Data, game)
 	}
 
 	return results, nil
 }
 
+// FindTimedGames returns the timed games in play on their snapshot, the ones that may run out of time.
+// The snapshot is stored every time the state changes, so it tells whether the game is still in play.
+func (gameRepository *GameRepository) FindTimedGames() ([]*models.Game, error) {
+	filter := bson.M{"state": models.Playing, "time_limit_ms": bson.M{"$gt": 0}}
+	cur, err := gameRepository.dataBaseProvider.Find(gameCollection, filter, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close(context.TODO())
+
+	var results []*models.Game
+	for cur.Next(context.TODO()) {
+		var game *models.Game
+		if err := cur.Decode(&game); err != nil {
+			return nil, fmt.Errorf("error marshal from database: %v", err)
+		}
+		results = append(results, game)
+	}
+
+	return results, nil
+}
+
 func (gameRepository *GameRepository) GetGame(gameId string) (*models.Game, error) {
 	objID, err := primitive.ObjectIDFromHex(gameId)
 	if err != nil {
@@ -121,23 +131,131 @@ func (gameRepository *GameRepository) GetGame(gameId string) (*models.Game, erro
 	if err := sr.Decode(&result); err != nil {
 		return nil, err
 	}
+	if err := gameRepository.replay(result); err != nil {
+		return nil, err
+	}
 
 	return result, nil
 }
 
+// GetChunk returns the chunk saved by an earlier move, or nil when no move touched it yet
+func (gameRepository *GameRepository) GetChunk(gameId string, row int, column int) (*models.Chunk, error) {
+	var result *models.Chunk
 
-func (gameRepository *GameRepository) resumeGame(gameId string) (bool, error) {
-	objID, err := primitive.ObjectIDFromHex(gameId)
+	sr, err := gameRepository.dataBaseProvider.GetById(chunkCollection, chunkId(gameId, row, column))
 	if err != nil {
-		return false, fmt.Errorf("not can create object_id: %v", err)
+		return nil, err
 	}
 
-	query := bson.M{}
-	newState := bson.M{}
-	newState["state"] = models.Playing
-	query["$set"] = newState
+	if err := sr.Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	result.MigrateFlags()
+
+	return result, nil
+}
+
+func (gameRepository *GameRepository) FindChunks(gameId string) ([]*models.Chunk, error) {
+	filter := bson.M{"game_id": gameId}
+	cur, err := gameRepository.dataBaseProvider.Find(chunkCollection, filter, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close(context.TODO())
+
+	var results []*models.Chunk
+	for cur.Next(context.TODO()) {
+		var chunk *models.Chunk
+		if err := cur.Decode(&chunk); err != nil {
+			return nil, fmt.Errorf("error marshal from database: %v", err)
+		}
+		chunk.MigrateFlags()
+		results = append(results, chunk)
+	}
 
-	return gameRepository.dataBaseProvider.Update(gameCollection, objID, query)
+	return results, nil
+}
+
+func (gameRepository *GameRepository) SaveChunks(gameId string, chunks []*models.Chunk) error {
+	for _, chunk := range chunks {
+		chunk.Id = chunkId(gameId, chunk.Row, chunk.Column)
+		chunk.GameId = gameId
+		if err := gameRepository.dataBaseProvider.Upsert(chunkCollection, chunk.Id, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func chunkId(gameId string, row int, column int) string {
+	return fmt.Sprintf("%s:%d:%d", gameId, row, column)
+}
+
+// replay brings the snapshot of the game up to date, applying the events stored after it
+func (gameRepository *GameRepository) replay(game *models.Game) error {
+	game.MigrateFlags()
+	events, err := gameRepository.FindEvents(game.Id.Hex(), game.Version)
+	if err != nil {
+		return err
+	}
+	return gameRepository.applyEvents(game, events)
+}
+
+func (gameRepository *GameRepository) applyEvents(game *models.Game, events []*models.GameEvent) error {
+	gameId := game.Id.Hex()
+	loader := func(row int, column int) (*models.Chunk, error) {
+		return gameRepository.GetChunk(gameId, row, column)
+	}
+	for _, event := range events {
+		if err := game.ApplyEvent(event, loader); err != nil {
+			return fmt.Errorf("not can apply the event %d of the game %s: %v", event.Seq, gameId, err)
+		}
+	}
+	return nil
+}
+
+// AppendEvents stores the events of a move at once. An event is only stored once, so when two moves are
+// made at the same time on the same game the one stored last gets ErrVersionConflict and nothing is stored.
+func (gameRepository *GameRepository) AppendEvents(gameId string, events []*models.GameEvent) error {
+	documents := make([]interface{}, len(events))
+	for i, event := range events {
+		event.Id = fmt.Sprintf("%s:%d", gameId, event.Seq)
+		event.GameId = gameId
+		documents[i] = event
+	}
+	if err := gameRepository.dataBaseProvider.InsertMany(eventCollection, documents); err != nil {
+		if errors.Is(err, infrastructure.ErrDuplicateKey) {
+			return models.ErrVersionConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// FindEvents returns the events of the game after the seq, in order
+func (gameRepository *GameRepository) FindEvents(gameId string, afterSeq int) ([]*models.GameEvent, error) {
+	filter := bson.M{"game_id": gameId, "seq": bson.M{"$gt": afterSeq}}
+	cur, err := gameRepository.dataBaseProvider.Find(eventCollection, filter, options.Find().SetSort(bson.M{"seq": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close(context.TODO())
+
+	var results []*models.GameEvent
+	for cur.Next(context.TODO()) {
+		var event *models.GameEvent
+		if err := cur.Decode(&event); err != nil {
+			return nil, fmt.Errorf("error marshal from database: %v", err)
+		}
+		results = append(results, event)
+	}
+
+	return results, nil
 }
 
 func NewGameRepository() IGameRepository {
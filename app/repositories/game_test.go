@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/pedidosya/minesweeper-API/app/models"
+	"github.com/pedidosya/minesweeper-API/infrastructure"
+	"github.com/pedidosya/minesweeper-API/mocks/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+func TestGameRepository_AppendEvents(t *testing.T) {
+
+	dataBaseProviderMock := &mocks.DataBaseProviderMock{}
+
+	type args struct {
+		gameId string
+		events []*models.GameEvent
+	}
+	tests := []struct {
+		name        string
+		initMocks   func()
+		args        args
+		assertMocks func(*testing.T)
+		assertError func(*testing.T, error)
+	}{
+		{
+			name: "Error - Another move stored first",
+			initMocks: func() {
+				dataBaseProviderMock.On("InsertMany", eventCollection, mock.Anything).
+					Return(fmt.Errorf("%w in collection: %s", infrastructure.ErrDuplicateKey, eventCollection)).Once()
+			},
+			args: args{
+				gameId: "game",
+				events: []*models.GameEvent{{Seq: 3, Type: models.EventPaused}},
+			},
+			assertMocks: func(t *testing.T) {
+				dataBaseProviderMock.AssertExpectations(t)
+			},
+			assertError: func(t *testing.T, e error) {
+				assert.Equal(t, models.ErrVersionConflict, e)
+			},
+		},
+		{
+			name: "Error - Insert Events",
+			initMocks: func() {
+				dataBaseProviderMock.On("InsertMany", eventCollection, mock.Anything).
+					Return(fmt.Errorf("error when invoke database")).Once()
+			},
+			args: args{
+				gameId: "game",
+				events: []*models.GameEvent{{Seq: 3, Type: models.EventPaused}},
+			},
+			assertMocks: func(t *testing.T) {
+				dataBaseProviderMock.AssertExpectations(t)
+			},
+			assertError: func(t *testing.T, e error) {
+				assert.NotNil(t, e)
+				assert.NotEqual(t, models.ErrVersionConflict, e)
+			},
+		},
+		{
+			name: "Success - Move and its end inserted at once",
+			initMocks: func() {
+				dataBaseProviderMock.On("InsertMany", eventCollection, mock.MatchedBy(func(documents []interface{}) bool {
+					return len(documents) == 2 &&
+						documents[0].(*models.GameEvent).Id == "game:3" && documents[0].(*models.GameEvent).GameId == "game" &&
+						documents[1].(*models.GameEvent).Id == "game:4" && documents[1].(*models.GameEvent).GameId == "game"
+				})).Return(nil).Once()
+			},
+			args: args{
+				gameId: "game",
+				events: []*models.GameEvent{{Seq: 3, Type: models.EventUncovered}, {Seq: 4, Type: models.EventWon}},
+			},
+			assertMocks: func(t *testing.T) {
+				dataBaseProviderMock.AssertExpectations(t)
+			},
+			assertError: func(t *testing.T, e error) {
+				assert.Nil(t, e)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameRepository := &GameRepository{
+				dataBaseProvider: dataBaseProviderMock,
+			}
+			tt.initMocks()
+			err := gameRepository.AppendEvents(tt.args.gameId, tt.args.events)
+			tt.assertMocks(t)
+			tt.assertError(t, err)
+		})
+	}
+}
+
+func TestGameRepository_FindEvents(t *testing.T) {
+
+	dataBaseProviderMock := &mocks.DataBaseProviderMock{}
+	dataBaseProviderMock.On("Find", eventCollection, bson.M{"game_id": "game", "seq": bson.M{"$gt": 2}}).
+		Return(nil, fmt.Errorf("error when invoke database")).Once()
+	gameRepository := &GameRepository{
+		dataBaseProvider: dataBaseProviderMock,
+	}
+
+	events, err := gameRepository.FindEvents("game", 2)
+
+	dataBaseProviderMock.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Nil(t, events)
+}
+
+func TestGameRepository_replay(t *testing.T) {
+
+	dataBaseProviderMock := &mocks.DataBaseProviderMock{}
+	game := &models.Game{Id: primitive.NewObjectID(), Version: 5}
+	dataBaseProviderMock.On("Find", eventCollection, bson.M{"game_id": game.Id.Hex(), "seq": bson.M{"$gt": 5}}).
+		Return(nil, fmt.Errorf("error when invoke database")).Once()
+	gameRepository := &GameRepository{
+		dataBaseProvider: dataBaseProviderMock,
+	}
+
+	err := gameRepository.replay(game)
+
+	dataBaseProviderMock.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, 5, game.Version)
+}
+
+func TestGameRepository_applyEvents(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		events      []*models.GameEvent
+		wantVersion int
+		wantState   models.StateGame
+		wantErr     bool
+	}{
+		{
+			name: "Success - Events after the snapshot",
+			events: []*models.GameEvent{
+				{Seq: 3, Type: models.EventPaused, At: start.Add(time.Second)},
+				{Seq: 4, Type: models.EventResumed, At: start.Add(5 * time.Second)},
+			},
+			wantVersion: 4,
+			wantState:   models.Playing,
+		},
+		{
+			name: "Error - Event missing",
+			events: []*models.GameEvent{
+				{Seq: 3, Type: models.EventPaused, At: start.Add(time.Second)},
+				{Seq: 5, Type: models.EventResumed, At: start.Add(5 * time.Second)},
+			},
+			wantVersion: 3,
+			wantState:   models.Paused,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameRepository := &GameRepository{
+				dataBaseProvider: &mocks.DataBaseProviderMock{},
+			}
+			game := &models.Game{Id: primitive.NewObjectID(), State: models.Playing, StartedAt: &start, Version: 2}
+
+			err := gameRepository.applyEvents(game, tt.events)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantVersion, game.Version)
+			assert.Equal(t, tt.wantState, game.State)
+			assert.Len(t, game.Pauses, 1)
+		})
+	}
+}
@@ -16,10 +16,20 @@ func Routes(s *server.Server) {
 
 	handlerGame := handlers.NewHandlerGame()
 	s.AddRoute("/v{version}/games", handlerGame.NewGame, http.MethodPost)
+	s.AddRoute("/v{version}/games/{game_id}", handlerGame.GetGame, http.MethodGet)
 	s.AddRoute("/v{version}/games/{game_id}/pause", handlerGame.PauseGame, http.MethodPut)
 	s.AddRoute("/v{version}/games/{game_id}/resume", handlerGame.ResumeGame, http.MethodPut)
+	s.AddRoute("/v{version}/games/{game_id}/undo", handlerGame.Undo, http.MethodPut)
+	s.AddRoute("/v{version}/games/{game_id}/redo", handlerGame.Redo, http.MethodPut)
 	s.AddRoute("/v{version}/games/{game_id}/mark-red", handlerGame.MarkRed, http.MethodPut)
 	s.AddRoute("/v{version}/games/{game_id}/mark-question", handlerGame.MarkQuestion, http.MethodPut)
+	s.AddRoute("/v{version}/games/{game_id}/unmark", handlerGame.Unmark, http.MethodPut)
+	s.AddRoute("/v{version}/games/{game_id}/cycle-mark", handlerGame.CycleMark, http.MethodPut)
 	s.AddRoute("/v{version}/games/{game_id}/uncover", handlerGame.Uncover, http.MethodPut)
+	s.AddRoute("/v{version}/games/{game_id}/chord", handlerGame.Chord, http.MethodPut)
+	s.AddRoute("/v{version}/games/{game_id}/hint", handlerGame.Hint, http.MethodGet)
+	s.AddRoute("/v{version}/games/{game_id}/probabilities", handlerGame.Probabilities, http.MethodGet)
+	s.AddRoute("/v{version}/games/{game_id}/replay", handlerGame.Replay, http.MethodGet)
+	s.AddRoute("/v{version}/games/{game_id}/replay/step", handlerGame.ReplayStep, http.MethodGet)
 	s.AddRoute("/v{version}/games", handlerGame.FindGames, http.MethodGet)
 }
@@ -3,4 +3,17 @@ package server
 const (
 	ErrorCodeMissingParams string = "MISSING_PARAMS"
 	ErrorCodeInvalidParams string = "INVALID_PARAMS"
+	// ErrorCodeGamePaused and ErrorCodeGameFinished tell why the state of the game does not allow the action
+	ErrorCodeGamePaused   string = "GAME_PAUSED"
+	ErrorCodeGameFinished string = "GAME_FINISHED"
+	// ErrorCodeCellOpen tells a mark was put on or taken off an open cell
+	ErrorCodeCellOpen string = "CELL_OPEN"
+	// ErrorCodeUndoNotAllowed tells there is no move to undo or redo, or the game may not undo it
+	ErrorCodeUndoNotAllowed string = "UNDO_NOT_ALLOWED"
+	// ErrorCodeGameInPlay tells the game has to be won or lost first
+	ErrorCodeGameInPlay string = "GAME_IN_PLAY"
+	// ErrorCodeNotAvailable tells the board of the game does not have the action
+	ErrorCodeNotAvailable string = "NOT_AVAILABLE"
+	// ErrorCodeVersionConflict tells another move on the game was stored first
+	ErrorCodeVersionConflict string = "VERSION_CONFLICT"
 )
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/handlers"
@@ -77,6 +78,22 @@ func GetStringFromPath(r *http.Request, key string, defaultValue string) string
 	return str
 }
 
+// GetIntFromQuery returns the query parameter as a number, or the default value when the request has none
+func GetIntFromQuery(r *http.Request, key string, defaultValue int) (int, error) {
+	str := r.URL.Query().Get(key)
+
+	if len(str) < 1 {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number", key)
+	}
+
+	return value, nil
+}
+
 func OK(w http.ResponseWriter, r *http.Request, obj interface{}) {
 	Render(w, r, obj, http.StatusOK)
 }
@@ -114,6 +131,14 @@ func Forbidden(w http.ResponseWriter, r *http.Request, messages ...string) {
 	Render(w, r, err, http.StatusForbidden)
 }
 
+func Conflict(w http.ResponseWriter, r *http.Request, code string, messages ...string) {
+	err := &errorResponse{
+		Code:     code,
+		Messages: messages,
+	}
+	Render(w, r, err, http.StatusConflict)
+}
+
 func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	Render(w, r, &errorResponse{
 		Code:     "INTERNAL_SERVER_ERROR",
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"github.com/pedidosya/minesweeper-API/app/models"
+	"github.com/pedidosya/minesweeper-API/utils"
+)
+
+// newEndlessGame stores the game and uncovers the cell 0, 0, so the player starts from an opening.
+// The opening comes with the created event, it is no move of the player.
+func (service *GameService) newEndlessGame(game *models.Game, event *models.GameEvent) (*models.Game, error) {
+	if _, err := service.gameRepository.NewGame(game); err != nil {
+		return nil, err
+	}
+
+	id := game.Id.Hex()
+	if err := service.gameRepository.AppendEvents(id, []*models.GameEvent{event}); err != nil {
+		return nil, err
+	}
+	if err := game.UncoverEndless(&models.CellRequest{}, nil); err != nil {
+		return nil, err
+	}
+	if err := service.gameRepository.SaveChunks(id, game.Endless.Chunks); err != nil {
+		return nil, err
+	}
+	if err := service.gameRepository.UpdateGame(id, game); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (service *GameService) saveEndless(id string, game *models.Game) {
+	if err := service.gameRepository.SaveChunks(id, game.Endless.Chunks); err != nil {
+		utils.LogError(err)
+		return
+	}
+	if err := service.gameRepository.UpdateGame(id, game); err != nil {
+		utils.LogError(err)
+	}
+}
+
+// resumeEndless returns every chunk stored so far with the game, so the player sees the whole board again
+func (service *GameService) resumeEndless(id string, game *models.Game) (*models.Game, error) {
+	chunks, err := service.gameRepository.FindChunks(id)
+	if err != nil {
+		return nil, err
+	}
+	game.Endless.Chunks = chunks
+	return game, nil
+}
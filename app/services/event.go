@@ -0,0 +1,87 @@
+package services
+
+import (
+	"github.com/pedidosya/minesweeper-API/app/models"
+	"github.com/pedidosya/minesweeper-API/utils"
+	"github.com/spf13/viper"
+	"time"
+)
+
+// defaultSnapshotInterval is used when the configuration has no snapshot interval
+const defaultSnapshotInterval = 20
+
+// ExpireGame loses the game when its time is up, the sweeper expires the games nobody plays any more with it
+func (service *GameService) ExpireGame(id string) (bool, error) {
+	game, err := service.gameRepository.GetGame(id)
+	if err != nil {
+		return false, err
+	}
+	return service.expire(id, game, service.clock())
+}
+
+// transition loses the game first when its time is up, so the action is checked against the state the game is really in
+func (service *GameService) transition(id string, game *models.Game, action models.GameAction, now time.Time) error {
+	if _, err := service.expire(id, game, now); err != nil {
+		return err
+	}
+	return game.Allows(action)
+}
+
+func (service *GameService) expire(id string, game *models.Game, now time.Time) (bool, error) {
+	if !game.TimeUp(now) {
+		return false, nil
+	}
+	event := game.NewEvent(models.EventLost, now)
+	event.LossReason = models.LossReasonTimeout
+	return true, service.apply(id, game, event)
+}
+
+// apply makes the action of the event on the game and stores the event, followed by a won or lost event
+// when it ended the game. The snapshot is stored every few events and every time the state changes,
+// before apply returns: the caller goes on changing the game. The events are stored already, so a snapshot
+// that fails is only logged, the next one covers it.
+func (service *GameService) apply(id string, game *models.Game, event *models.GameEvent) error {
+	loader := func(row int, column int) (*models.Chunk, error) {
+		return service.gameRepository.GetChunk(id, row, column)
+	}
+	previous := game.State
+	if err := game.ApplyEvent(event, loader); err != nil {
+		return err
+	}
+
+	events := []*models.GameEvent{event}
+	if previous == models.Playing && (game.State == models.Won || game.State == models.Lose) && event.Type != models.EventLost {
+		end := game.NewEvent(models.EventWon, event.At)
+		if game.State == models.Lose {
+			end.Type = models.EventLost
+			end.LossReason = game.LossReason
+		}
+		if err := game.ApplyEvent(end, loader); err != nil {
+			return err
+		}
+		events = append(events, end)
+	}
+	if err := service.gameRepository.AppendEvents(id, events); err != nil {
+		return err
+	}
+
+	// endless games keep their board in the chunks, which are stored after every move
+	if game.Endless != nil {
+		service.saveEndless(id, game)
+		return nil
+	}
+	if game.SnapshotDue(previous, len(events), snapshotInterval()) {
+		if err := service.gameRepository.UpdateGame(id, game); err != nil {
+			utils.LogError(err)
+		}
+	}
+	return nil
+}
+
+func snapshotInterval() int {
+	interval := viper.GetInt("events.snapshotInterval")
+	if interval <= 0 {
+		return defaultSnapshotInterval
+	}
+	return interval
+}
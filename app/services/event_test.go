@@ -0,0 +1,107 @@
+package services
+
+import (
+	"fmt"
+	"github.com/pedidosya/minesweeper-API/app/models"
+	"github.com/pedidosya/minesweeper-API/mocks/app/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestGameService_apply(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		game        func() *models.Game
+		event       models.EventType
+		initMocks   func(*repositories.GameRepositoryMock)
+		assertError func(*testing.T, error)
+	}{
+		{
+			name: "Success - Snapshot stored before returning",
+			game: func() *models.Game {
+				return &models.Game{State: models.Playing, StartedAt: &start, Version: 2}
+			},
+			event: models.EventPaused,
+			initMocks: func(gameRepositoryMock *repositories.GameRepositoryMock) {
+				gameRepositoryMock.On("AppendEvents", "game", mock.Anything).Return(nil).Once()
+				gameRepositoryMock.On("UpdateGame", "game", mock.MatchedBy(func(game *models.Game) bool {
+					return game.Version == 3 && game.State == models.Paused
+				})).Return(nil).Once()
+			},
+			assertError: func(t *testing.T, e error) {
+				assert.Nil(t, e)
+			},
+		},
+		{
+			name: "Success - Snapshot failed is only logged",
+			game: func() *models.Game {
+				return &models.Game{State: models.Playing, StartedAt: &start, Version: 2}
+			},
+			event: models.EventPaused,
+			initMocks: func(gameRepositoryMock *repositories.GameRepositoryMock) {
+				gameRepositoryMock.On("AppendEvents", "game", mock.Anything).Return(nil).Once()
+				gameRepositoryMock.On("UpdateGame", "game", mock.Anything).
+					Return(fmt.Errorf("error when invoke database")).Once()
+			},
+			assertError: func(t *testing.T, e error) {
+				assert.Nil(t, e)
+			},
+		},
+		{
+			name: "Success - Endless chunks stored before returning",
+			game: func() *models.Game {
+				return &models.Game{State: models.Playing, StartedAt: &start, Seed: 1, Endless: models.NewEndlessBoard(40), Version: 2}
+			},
+			event: models.EventUncovered,
+			initMocks: func(gameRepositoryMock *repositories.GameRepositoryMock) {
+				gameRepositoryMock.On("GetChunk", "game", mock.Anything, mock.Anything).Return(nil, nil)
+				gameRepositoryMock.On("AppendEvents", "game", mock.Anything).Return(nil).Once()
+				gameRepositoryMock.On("SaveChunks", "game", mock.MatchedBy(func(chunks []*models.Chunk) bool {
+					return len(chunks) > 0
+				})).Return(nil).Once()
+				gameRepositoryMock.On("UpdateGame", "game", mock.MatchedBy(func(game *models.Game) bool {
+					return game.Version == 3
+				})).Return(nil).Once()
+			},
+			assertError: func(t *testing.T, e error) {
+				assert.Nil(t, e)
+			},
+		},
+		{
+			name: "Error - Append Events",
+			game: func() *models.Game {
+				return &models.Game{State: models.Playing, StartedAt: &start, Version: 2}
+			},
+			event: models.EventPaused,
+			initMocks: func(gameRepositoryMock *repositories.GameRepositoryMock) {
+				gameRepositoryMock.On("AppendEvents", "game", mock.Anything).Return(models.ErrVersionConflict).Once()
+			},
+			assertError: func(t *testing.T, e error) {
+				assert.Equal(t, models.ErrVersionConflict, e)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameRepositoryMock := &repositories.GameRepositoryMock{}
+			service := &GameService{
+				gameRepository: gameRepositoryMock,
+				clock:          func() time.Time { return start },
+			}
+			tt.initMocks(gameRepositoryMock)
+			game := tt.game()
+			event := game.NewEvent(tt.event, start.Add(time.Second))
+			event.Cell = &models.CellRequest{}
+
+			err := service.apply("game", game, event)
+
+			// every store is made by the time apply returns, the caller may change the game right after
+			gameRepositoryMock.AssertExpectations(t)
+			tt.assertError(t, err)
+		})
+	}
+}
@@ -4,99 +4,406 @@ import (
 	"fmt"
 	"github.com/pedidosya/minesweeper-API/app/models"
 	"github.com/pedidosya/minesweeper-API/app/repositories"
+	"github.com/pedidosya/minesweeper-API/utils"
+	"github.com/spf13/viper"
+	"math/rand"
+	"time"
 )
 
 type IGameService interface {
-	NewGame(rows int, columns int, mines int, userName string) (interface{}, error)
+	NewGame(request *models.NewGameRequest, userName string) (*models.Game, error)
+	GetGame(id string, userName string) (*models.Game, error)
 	PauseGame(id string) (bool, error)
 	ResumeGame(id string, userName string) (*models.Game, error)
-	MarkRed(id string, row int, column int) (bool, error)
-	MarkQuestion(id string, row int, column int) (bool, error)
-	Uncover(id string, row int, column int) (*models.Game, error)
+	Undo(id string) (*models.Game, error)
+	Redo(id string) (*models.Game, error)
+	MarkRed(id string, cell *models.CellRequest) (bool, error)
+	MarkQuestion(id string, cell *models.CellRequest) (bool, error)
+	Unmark(id string, cell *models.CellRequest) (bool, error)
+	CycleMark(id string, cell *models.CellRequest) (bool, error)
+	Uncover(id string, cell *models.CellRequest) (*models.Game, error)
+	Chord(id string, cell *models.CellRequest) (*models.Game, error)
+	Hint(id string) (*models.Hint, error)
+	ExpireGame(id string) (bool, error)
+	Probabilities(id string) (*models.ProbabilityMap, error)
 	FindGames(user string) (*models.GameDto, error)
+	Replay(id string) (*models.Replay, error)
+	ReplayStep(id string, cursor int) (*models.ReplayStep, int, error)
 }
 
+// probabilityBudget is the time the probabilities of a large board may be sampled for
+const probabilityBudget = 2 * time.Second
+
 type GameService struct {
 	gameRepository repositories.IGameRepository
+	clock          models.Clock
 }
 
-func (service *GameService) NewGame(boardSizeX int, boardSizeY int, mines int, userName string) (interface{}, error) {
-	board := generateBoard(boardSizeX, boardSizeY, mines)
-	return service.gameRepository.NewGame(board, userName)
+func (service *GameService) NewGame(request *models.NewGameRequest, userName string) (*models.Game, error) {
+	now := service.clock()
+	seed := request.Seed
+	if seed == 0 {
+		var err error
+		if seed, err = utils.NewSeed(); err != nil {
+			return nil, fmt.Errorf("it's not possible to generate the seed, error :%v", err)
+		}
+	}
+
+	game := buildGame(request, userName, seed)
+	if game.Board != nil {
+		game.UndoLimit = undoLimit(game.Board.Mode)
+	}
+
+	// the stored game is the snapshot of the created event, which keeps what the board was made from
+	created := game.NewEvent(models.EventCreated, now)
+	created.Request = request
+	created.Seed = seed
+	created.UndoLimit = game.UndoLimit
+	if err := game.ApplyEvent(created, nil); err != nil {
+		return nil, err
+	}
+	if game.Endless != nil {
+		return service.newEndlessGame(game, created)
+	}
+	if _, err := service.gameRepository.NewGame(game); err != nil {
+		return nil, err
+	}
+	if err := service.gameRepository.AppendEvents(game.Id.Hex(), []*models.GameEvent{created}); err != nil {
+		return nil, err
+	}
+	return game, nil
 }
 
-func (service *GameService) PauseGame(id string) (bool, error) {
-	return service.gameRepository.PauseGame(id)
+// buildGame makes the game of the request before any event, the same request and seed always make the same game
+func buildGame(request *models.NewGameRequest, userName string, seed int64) *models.Game {
+	game := &models.Game{
+		UserName:    userName,
+		Seed:        seed,
+		TimeLimitMs: int64(request.TimeLimit) * 1000,
+	}
+	if request.Endless {
+		game.Endless = models.NewEndlessBoard(request.Mines)
+		game.Ranked = true
+		return game
+	}
+	game.Board = generateBoard(request, game.NewRandom())
+	game.Ranked = game.Board.Ranked()
+	game.Lives = game.Board.StartingLives(request.Lives)
+	return game
 }
 
-func (service *GameService) ResumeGame(id string, userName string) (*models.Game, error) {
-	return service.gameRepository.ResumeGame(id, userName)
+// GetGame returns the game as it is now. The player of the game sees it lost first when its time is up,
+// other players only see it once it is finished and leave it as it is, the sweeper expires the games left.
+func (service *GameService) GetGame(id string, userName string) (*models.Game, error) {
+	now := service.clock()
+	game, err := service.gameRepository.GetGame(id)
+	if err != nil {
+		return nil, err
+	}
+	if game.UserName != userName {
+		if !game.Finished() {
+			return nil, models.ErrForeignGame
+		}
+	} else if _, err := service.expire(id, game, now); err != nil {
+		return nil, err
+	}
+	game.UpdateClock(now)
+	if game.Endless != nil {
+		return service.resumeEndless(id, game)
+	}
+	return game, nil
 }
 
-func (service *GameService) MarkRed(id string, row int, column int) (bool, error) {
+func (service *GameService) PauseGame(id string) (bool, error) {
+	now := service.clock()
 	game, err := service.gameRepository.GetGame(id)
 	if err != nil {
 		return false, err
 	}
-	if err := validateSizeGameToAction(game.Board, row, column); err != nil {
+	if err := service.transition(id, game, models.ActionPause, now); err != nil {
+		return false, err
+	}
+	if err := service.apply(id, game, game.NewEvent(models.EventPaused, now)); err != nil {
 		return false, err
 	}
-	game.Board.MarkRed(row, column)
-	go service.gameRepository.UpdateGame(id, game)
 	return true, nil
 }
 
-func (service *GameService) MarkQuestion(id string, row int, column int) (bool, error) {
+func (service *GameService) ResumeGame(id string, userName string) (*models.Game, error) {
+	now := service.clock()
+	game, err := service.gameRepository.GetGame(id)
+	if err != nil {
+		return nil, err
+	}
+	if game.UserName != userName {
+		return nil, fmt.Errorf("the game belongs to another user")
+	}
+	if err := service.transition(id, game, models.ActionResume, now); err != nil {
+		return nil, err
+	}
+	// resuming a game in play changes nothing, so there is no event to store
+	if game.State == models.Paused {
+		if err := service.apply(id, game, game.NewEvent(models.EventResumed, now)); err != nil {
+			return nil, err
+		}
+	}
+	game.UpdateClock(now)
+	if game.Endless != nil {
+		return service.resumeEndless(id, game)
+	}
+	return game, nil
+}
+
+func (service *GameService) Undo(id string) (*models.Game, error) {
+	return service.undo(id, models.ActionUndo, models.EventUndone)
+}
+
+func (service *GameService) Redo(id string) (*models.Game, error) {
+	return service.undo(id, models.ActionRedo, models.EventRedone)
+}
+
+// undo undoes or redoes the last move of the game, endless games keep no moves
+func (service *GameService) undo(id string, action models.GameAction, eventType models.EventType) (*models.Game, error) {
+	now := service.clock()
+	game, err := service.gameRepository.GetGame(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.transition(id, game, action, now); err != nil {
+		return nil, err
+	}
+	if game.Endless != nil {
+		return nil, fmt.Errorf("%w: undo on endless games", models.ErrNotAvailable)
+	}
+
+	if err := service.apply(id, game, game.NewEvent(eventType, now)); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// undoLimit is the number of undos the games of the mode may use, modes left out of the configuration have none
+func undoLimit(mode models.GameModeType) int {
+	return viper.GetInt("undo." + string(mode))
+}
+
+func (service *GameService) MarkRed(id string, cell *models.CellRequest) (bool, error) {
+	return service.mark(id, cell, models.ActionMarkRed)
+}
+
+func (service *GameService) MarkQuestion(id string, cell *models.CellRequest) (bool, error) {
+	return service.mark(id, cell, models.ActionMarkQuestion)
+}
+
+func (service *GameService) Unmark(id string, cell *models.CellRequest) (bool, error) {
+	return service.mark(id, cell, models.ActionUnmark)
+}
+
+func (service *GameService) CycleMark(id string, cell *models.CellRequest) (bool, error) {
+	return service.mark(id, cell, models.ActionCycleMark)
+}
+
+// mark changes the mark of the cell with the move of the action
+func (service *GameService) mark(id string, cell *models.CellRequest, action models.GameAction) (bool, error) {
+	now := service.clock()
 	game, err := service.gameRepository.GetGame(id)
 	if err != nil {
 		return false, err
 	}
-	if err := validateSizeGameToAction(game.Board, row, column); err != nil {
+	if err := service.transition(id, game, action, now); err != nil {
+		return false, err
+	}
+	if game.Endless == nil {
+		if err := validateSizeGameToAction(game.Board, cell); err != nil {
+			return false, err
+		}
+		if action == models.ActionMarkRed {
+			if err := validateFlagCount(game.Board, cell); err != nil {
+				return false, err
+			}
+		}
+	} else if err := models.ValidateEndlessCell(cell); err != nil {
+		return false, err
+	}
+
+	event := game.NewEvent(models.EventFlagged, now)
+	event.Cell = cell
+	event.Action = action
+	if err := service.apply(id, game, event); err != nil {
 		return false, err
 	}
-	game.Board.MarkQuestion(row, column)
-	go service.gameRepository.UpdateGame(id, game)
 	return true, nil
 }
 
-func (service *GameService) Uncover(id string, row int, column int) (*models.Game, error) {
+func (service *GameService) Uncover(id string, cell *models.CellRequest) (*models.Game, error) {
+	return service.move(id, cell, models.ActionUncover, models.EventUncovered)
+}
+
+func (service *GameService) Chord(id string, cell *models.CellRequest) (*models.Game, error) {
+	return service.move(id, cell, models.ActionChord, models.EventChorded)
+}
+
+// move uncovers or chords the cell, endless games take any cell within their bounds
+func (service *GameService) move(id string, cell *models.CellRequest, action models.GameAction, eventType models.EventType) (*models.Game, error) {
+	now := service.clock()
 	game, err := service.gameRepository.GetGame(id)
 	if err != nil {
 		return nil, err
 	}
-	if err := validateSizeGameToAction(game.Board, row, column); err != nil {
+	if err := service.transition(id, game, action, now); err != nil {
+		return nil, err
+	}
+	if game.Endless == nil {
+		if err := validateSizeGameToAction(game.Board, cell); err != nil {
+			return nil, err
+		}
+	} else if err := models.ValidateEndlessCell(cell); err != nil {
 		return nil, err
 	}
 
-	game.UncoverCell(row, column)
-	go service.gameRepository.UpdateGame(id, game)
+	event := game.NewEvent(eventType, now)
+	event.Cell = cell
+	if err := service.apply(id, game, event); err != nil {
+		return nil, err
+	}
 	return game, nil
 }
 
-func validateSizeGameToAction(board *models.Board, row int, column int) error {
-	if board.Rows < row {
-		return fmt.Errorf("the row number must be less than: %d", board.Rows)
+func (service *GameService) Hint(id string) (*models.Hint, error) {
+	now := service.clock()
+	game, err := service.gameRepository.GetGame(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.transition(id, game, models.ActionHint, now); err != nil {
+		return nil, err
+	}
+	if game.Endless != nil {
+		return nil, fmt.Errorf("%w: hints on endless games", models.ErrNotAvailable)
+	}
+	if !game.Board.SupportsSolver() {
+		return nil, fmt.Errorf("%w: hints on %s boards", models.ErrNotAvailable, game.Board.Mode)
+	}
+
+	hint := game.Hint()
+	if hint.Found {
+		if err := service.apply(id, game, game.NewEvent(models.EventHinted, now)); err != nil {
+			return nil, err
+		}
 	}
+	return hint, nil
+}
 
-	if board.Columns < column {
-		return fmt.Errorf("the column number must be less than: %d", board.Columns)
+func (service *GameService) Probabilities(id string) (*models.ProbabilityMap, error) {
+	game, err := service.gameRepository.GetGame(id)
+	if err != nil {
+		return nil, err
+	}
+	if game.Endless != nil {
+		return nil, fmt.Errorf("%w: probabilities on endless games", models.ErrNotAvailable)
+	}
+	if !game.Board.SupportsSolver() {
+		return nil, fmt.Errorf("%w: probabilities on %s boards", models.ErrNotAvailable, game.Board.Mode)
+	}
+	return game.Board.MineProbabilities(game.NewRandom(), service.clock().Add(probabilityBudget)), nil
+}
+
+// validateSizeGameToAction checks the cell is on the board, hex boards use odd-r offset coordinates
+// so their rows and columns are checked just like the square ones. Flat boards take no layer or the first one.
+func validateSizeGameToAction(board *models.Board, cell *models.CellRequest) error {
+	layers := board.Layers
+	if layers == 0 {
+		layers = 1
+	}
+	if cell.Layer < 0 || layers < cell.Layer || (layers > 1 && cell.Layer == 0) {
+		return fmt.Errorf("the layer number must be between 1 and: %d", layers)
+	}
+
+	if cell.Row < 1 || board.Rows < cell.Row {
+		return fmt.Errorf("the row number must be between 1 and: %d", board.Rows)
+	}
+
+	if cell.Column < 1 || board.Columns < cell.Column {
+		return fmt.Errorf("the column number must be between 1 and: %d", board.Columns)
+	}
+
+	if board.IsVoid(cell) {
+		return fmt.Errorf("the cell is outside the shape of the board")
+	}
+	return nil
+}
+
+// validateFlagCount checks the mines a red flag claims, only multi-mine boards take a count
+func validateFlagCount(board *models.Board, cell *models.CellRequest) error {
+	maxCount := board.MinesPerCell()
+	if cell.Count < 0 || maxCount < cell.Count {
+		return fmt.Errorf("the count must be between 1 and: %d", maxCount)
 	}
 	return nil
 }
 
 func (service *GameService) FindGames(user string) (*models.GameDto, error) {
-	return service.gameRepository.FindGames(user)
+	games, err := service.gameRepository.FindGames(user)
+	if err != nil {
+		return nil, err
+	}
+	now := service.clock()
+	for _, game := range games.Data {
+		game.UpdateClock(now)
+	}
+	return games, nil
 }
 
-func generateBoard(rows int, columns int, mines int) *models.Board {
+func generateBoard(request *models.NewGameRequest, random *rand.Rand) *models.Board {
+	firstClick := request.FirstClick
+	if firstClick == "" {
+		firstClick = models.FirstClickSafeOpening
+	}
+
+	topology := request.Topology
+	if topology == "" {
+		topology = models.TopologyFlat
+	}
+
+	layout := request.Layout
+	if layout == "" {
+		layout = models.LayoutSquare
+	}
+
+	layers := request.Layers
+	if layers == 0 {
+		layers = 1
+	}
+
+	mode := request.Mode
+	if mode == "" {
+		mode = models.GameModeClassic
+	}
+
+	rows, columns := request.Rows, request.Columns
+	if request.Mask != "" {
+		if shape, err := models.ParseMask(request.Mask); err == nil {
+			rows, columns = shape.Rows, shape.Columns
+		}
+	}
+
 	var board = &models.Board{
-		Rows:    rows,
-		Columns: columns,
-		OpenCells: 0,
-		Mines:   mines,
-		Cells:   make([]*models.Cell, rows*columns),
+		Rows:       rows,
+		Columns:    columns,
+		OpenCells:  0,
+		Mines:      request.Mines,
+		FirstClick: firstClick,
+		Topology:   topology,
+		Layout:     layout,
+		Layers:     layers,
+		Mask:       request.Mask,
+		Mode:       mode,
+		NoGuess:    request.NoGuess,
+		Cells:      make([]*models.Cell, layers*rows*columns),
+		// only the modes whose cells hold several mines read it, the request has none for the others
+		MaxMinesPerCell: request.MaxMinesPerCell,
 	}
-	board.InitBoard()
+	board.InitBoard(random)
 
 	return board
 }
@@ -105,5 +412,6 @@ func NewGameService() IGameService {
 	gameRepository := repositories.NewGameRepository()
 	return &GameService{
 		gameRepository: gameRepository,
+		clock:          time.Now,
 	}
 }
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"fmt"
+	"github.com/pedidosya/minesweeper-API/app/models"
+)
+
+// Replay plays the events of a finished game again from its seed, returning the board after each one
+func (service *GameService) Replay(id string) (*models.Replay, error) {
+	replay := &models.Replay{GameId: id}
+	game, _, err := service.replayGame(id, func(game *models.Game, event *models.GameEvent) bool {
+		replay.Steps = append(replay.Steps, game.ReplayStep(event))
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	replay.Seed = game.Seed
+	return replay, nil
+}
+
+// ReplayStep returns the board of a finished game after the event of the cursor and the cursor of the next event,
+// which is zero after the last one
+func (service *GameService) ReplayStep(id string, cursor int) (*models.ReplayStep, int, error) {
+	var step *models.ReplayStep
+	_, last, err := service.replayGame(id, func(game *models.Game, event *models.GameEvent) bool {
+		if event.Seq == cursor {
+			step = game.ReplayStep(event)
+		}
+		return event.Seq < cursor
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if step == nil {
+		return nil, 0, models.ErrReplayCursor
+	}
+	if step.Seq == last {
+		return step, 0, nil
+	}
+	return step, step.Seq + 1, nil
+}
+
+// replayGame builds the game again from its created event and applies every event after it, calling visit after
+// each one until visit returns false, and returns the Seq of the last event of the game.
+// Only games finished for good are replayed, a game in play or a loss still undone would give its mines away.
+func (service *GameService) replayGame(id string, visit func(*models.Game, *models.GameEvent) bool) (*models.Game, int, error) {
+	stored, err := service.gameRepository.GetGame(id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !stored.Finished() {
+		return nil, 0, models.ErrReplayInPlay
+	}
+	events, err := service.gameRepository.FindEvents(id, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(events) == 0 || events[0].Type != models.EventCreated || events[0].Request == nil {
+		return nil, 0, fmt.Errorf("the game was created before its events were stored, it can't be replayed")
+	}
+
+	created := events[0]
+	game := buildGame(created.Request, stored.UserName, created.Seed)
+	game.Id = stored.Id
+	game.State = models.Playing
+	game.UndoLimit = created.UndoLimit
+	for _, event := range events {
+		if err := game.ApplyEvent(event, nil); err != nil {
+			return nil, 0, fmt.Errorf("not can replay the event %d of the game %s: %v", event.Seq, id, err)
+		}
+		// the endless board opens from the cell 0, 0 when it is created, chunks are generated again from the seed
+		if event.Type == models.EventCreated && game.Endless != nil {
+			if err := game.UncoverEndless(&models.CellRequest{}, nil); err != nil {
+				return nil, 0, err
+			}
+		}
+		if !visit(game, event) {
+			break
+		}
+	}
+	return game, events[len(events)-1].Seq, nil
+}
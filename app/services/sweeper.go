@@ -0,0 +1,75 @@
+package services
+
+import (
+	"github.com/pedidosya/minesweeper-API/app/repositories"
+	"github.com/pedidosya/minesweeper-API/utils"
+	"github.com/spf13/viper"
+	"time"
+)
+
+// defaultSweepInterval is used when the configuration has no sweeper interval
+const defaultSweepInterval = 10 * time.Second
+
+type IGameSweeper interface {
+	Start()
+	Stop()
+}
+
+// GameSweeper expires the timed games nobody plays any more, the games someone plays expire on their next move
+type GameSweeper struct {
+	gameRepository repositories.IGameRepository
+	gameService    IGameService
+	interval       time.Duration
+	stop           chan struct{}
+	done           chan struct{}
+}
+
+// Start sweeps the timed games every interval until Stop is called
+func (sweeper *GameSweeper) Start() {
+	go func() {
+		defer close(sweeper.done)
+		ticker := time.NewTicker(sweeper.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sweeper.sweep()
+			case <-sweeper.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the sweeps and waits for the one in progress
+func (sweeper *GameSweeper) Stop() {
+	close(sweeper.stop)
+	<-sweeper.done
+}
+
+func (sweeper *GameSweeper) sweep() {
+	games, err := sweeper.gameRepository.FindTimedGames()
+	if err != nil {
+		utils.LogError(err)
+		return
+	}
+	for _, game := range games {
+		if _, err := sweeper.gameService.ExpireGame(game.Id.Hex()); err != nil {
+			utils.LogError(err)
+		}
+	}
+}
+
+func NewGameSweeper() IGameSweeper {
+	interval := time.Duration(viper.GetInt("sweeper.interval")) * time.Second
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+	return &GameSweeper{
+		gameRepository: repositories.NewGameRepository(),
+		gameService:    NewGameService(),
+		interval:       interval,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/pedidosya/minesweeper-API/utils"
 	"github.com/spf13/viper"
@@ -17,6 +18,8 @@ type IDataBaseProvider interface {
 
 	Insert(collectionName string, val interface{}) (interface{}, error)
 
+	InsertMany(collectionName string, vals []interface{}) error
+
 	Upsert(collectionName string, id interface{}, val interface{}) error
 
 	Update(collectionName string, id interface{}, val interface{}) (bool, error)
@@ -30,6 +33,12 @@ type IDataBaseProvider interface {
 	Aggregate(collectionName string, pipeline interface{}, opts *options.AggregateOptions) (*mongo.Cursor, error)
 }
 
+// ErrDuplicateKey is returned when a document is inserted with the id of a stored one
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// duplicateKeyCode is the code MongoDB answers an insert of a stored id with
+const duplicateKeyCode = 11000
+
 type MongoDataBaseProvider struct {
 	client *mongo.Database
 }
@@ -48,7 +57,8 @@ func (provider *MongoDataBaseProvider) ConnectDatabase() (interface{}, error) {
 
 		uriDS := fmt.Sprintf("mongodb://%s:%s@%s/%s", dbUser, dbPassword, dbHost, dbName)
 		utils.LogInfo("Connecting with MongoDB...")
-		ctx, _ := context.WithTimeout(context.Background(), viper.GetDuration("database.timeout")*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("database.timeout")*time.Second)
+		defer cancel()
 		client, err := mongo.Connect(ctx, options.Client().ApplyURI(uriDS))
 
 		if err != nil {
@@ -80,6 +90,33 @@ func (provider *MongoDataBaseProvider) Insert(collectionName string, val interfa
 	return insertResult.InsertedID, nil
 }
 
+// InsertMany inserts the documents in order, it stops on the first one that can't be inserted
+func (provider *MongoDataBaseProvider) InsertMany(collectionName string, vals []interface{}) error {
+	collection := provider.client.Collection(collectionName)
+	insertResult, err := collection.InsertMany(context.TODO(), vals)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return fmt.Errorf("%w in collection: %s", ErrDuplicateKey, collectionName)
+		}
+		return fmt.Errorf("error to insert in collection: %s, %v", collectionName, err)
+	}
+	utils.LogInfo("inserted %d documents in collection: %s", len(insertResult.InsertedIDs), collectionName)
+	return nil
+}
+
+func isDuplicateKey(err error) bool {
+	var bulkWriteException mongo.BulkWriteException
+	if !errors.As(err, &bulkWriteException) {
+		return false
+	}
+	for _, writeError := range bulkWriteException.WriteErrors {
+		if writeError.Code == duplicateKeyCode {
+			return true
+		}
+	}
+	return false
+}
+
 func (provider *MongoDataBaseProvider) Upsert(collectionName string, id interface{}, val interface{}) error {
 	collection := provider.client.Collection(collectionName)
 	filter := bson.M{"_id": id}
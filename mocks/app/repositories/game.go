@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"github.com/pedidosya/minesweeper-API/app/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type GameRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *GameRepositoryMock) NewGame(game *models.Game) (interface{}, error) {
+	args := m.Called(game)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *GameRepositoryMock) UpdateGame(gameId string, game *models.Game) error {
+	args := m.Called(gameId, game)
+	return args.Error(0)
+}
+
+func (m *GameRepositoryMock) GetGame(gameId string) (*models.Game, error) {
+	args := m.Called(gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Game), args.Error(1)
+}
+
+func (m *GameRepositoryMock) FindGames(user string) (*models.GameDto, error) {
+	args := m.Called(user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameDto), args.Error(1)
+}
+
+func (m *GameRepositoryMock) FindTimedGames() ([]*models.Game, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Game), args.Error(1)
+}
+
+func (m *GameRepositoryMock) GetChunk(gameId string, row int, column int) (*models.Chunk, error) {
+	args := m.Called(gameId, row, column)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Chunk), args.Error(1)
+}
+
+func (m *GameRepositoryMock) FindChunks(gameId string) ([]*models.Chunk, error) {
+	args := m.Called(gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Chunk), args.Error(1)
+}
+
+func (m *GameRepositoryMock) SaveChunks(gameId string, chunks []*models.Chunk) error {
+	args := m.Called(gameId, chunks)
+	return args.Error(0)
+}
+
+func (m *GameRepositoryMock) AppendEvents(gameId string, events []*models.GameEvent) error {
+	args := m.Called(gameId, events)
+	return args.Error(0)
+}
+
+func (m *GameRepositoryMock) FindEvents(gameId string, afterSeq int) ([]*models.GameEvent, error) {
+	args := m.Called(gameId, afterSeq)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GameEvent), args.Error(1)
+}
@@ -28,11 +28,21 @@ func (m *DataBaseProviderMock) Insert(collectionName string, val interface{}) (i
 	return args.Get(0), args.Error(1)
 }
 
+func (m *DataBaseProviderMock) InsertMany(collectionName string, vals []interface{}) error {
+	args := m.Called(collectionName, vals)
+	return args.Error(0)
+}
+
 func (m *DataBaseProviderMock) Upsert(collectionName string, id interface{}, val interface{}) error {
 	args := m.Called(collectionName, id, val)
 	return args.Error(0)
 }
 
+func (m *DataBaseProviderMock) Update(collectionName string, id interface{}, val interface{}) (bool, error) {
+	args := m.Called(collectionName, id, val)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *DataBaseProviderMock) ReplaceById(collectionName string, id interface{}, val interface{}) error {
 	args := m.Called(collectionName, id, val)
 	return args.Error(0)